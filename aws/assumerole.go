@@ -0,0 +1,96 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// DefaultAssumeRoleExpiryWindow is how long before expiry assumed role
+// credentials are refreshed.
+const DefaultAssumeRoleExpiryWindow = 5 * time.Minute
+
+var ErrEmptyRoleArn = errors.New("role arn is empty")
+
+// AssumeRole describes one hop of a role chain.
+type AssumeRole struct {
+	RoleArn    string
+	ExternalID string
+	// SessionName defaults to the one generated by the AWS SDK.
+	SessionName string
+	// Duration defaults to 15 minutes. Chained roles are limited to 1 hour
+	// by STS.
+	Duration time.Duration
+}
+
+// NewAssumeRoleCredentials assumes roleArn with the credentials of source.
+func NewAssumeRoleCredentials(source CredentialSource, roleArn string) CredentialSource {
+	return NewRoleChainCredentials(source, AssumeRole{RoleArn: roleArn})
+}
+
+// NewRoleChainCredentials assumes roles in order, each one with the
+// credentials of the previous one, starting from source. This is how a hub
+// account reaches a workload account. Every hop is cached and refreshed
+// before it expires.
+func NewRoleChainCredentials(source CredentialSource, roles ...AssumeRole) CredentialSource {
+	return CredentialSourceFunc(func(ctx context.Context, base aws.Config) (aws.CredentialsProvider, error) {
+		provider, err := source.Resolve(ctx, base)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, role := range roles {
+			if strings.EqualFold(role.RoleArn, "") {
+				return nil, ErrEmptyRoleArn
+			}
+
+			cfg := base.Copy()
+			cfg.Credentials = provider
+			provider = aws.NewCredentialsCache(
+				stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), role.RoleArn, role.options),
+				func(o *aws.CredentialsCacheOptions) {
+					o.ExpiryWindow = DefaultAssumeRoleExpiryWindow
+					o.ExpiryWindowJitterFrac = 0.2
+				},
+			)
+		}
+		return provider, nil
+	})
+}
+
+func (r AssumeRole) options(o *stscreds.AssumeRoleOptions) {
+	if !strings.EqualFold(r.ExternalID, "") {
+		o.ExternalID = aws.String(r.ExternalID)
+	}
+	if !strings.EqualFold(r.SessionName, "") {
+		o.RoleSessionName = r.SessionName
+	}
+	if r.Duration > 0 {
+		o.Duration = r.Duration
+	}
+}
+
+// SetAssumeRoleCredential registers a session for account in region whose
+// credentials are obtained by assuming roles in order, starting from source.
+func (s *awsCreds) SetAssumeRoleCredential(account, region string, source CredentialSource, roles ...AssumeRole) *awsCreds {
+	return s.SetAccountCredentialSource(account, region, NewRoleChainCredentials(source, roles...))
+}
@@ -0,0 +1,123 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+const assumeRoleResponse = `<AssumeRoleResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <AssumeRoleResult>
+    <Credentials>
+      <AccessKeyId>%s</AccessKeyId>
+      <SecretAccessKey>secret</SecretAccessKey>
+      <SessionToken>token</SessionToken>
+      <Expiration>%s</Expiration>
+    </Credentials>
+  </AssumeRoleResult>
+</AssumeRoleResponse>`
+
+type assumeRoleCall struct {
+	accessKey  string
+	roleArn    string
+	externalID string
+	duration   string
+}
+
+func Test_RoleChainCredentials(t *testing.T) {
+	var mu sync.Mutex
+	calls := []assumeRoleCall{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("%+v\n", err)
+		}
+		call := assumeRoleCall{
+			accessKey:  signingAccessKey(r),
+			roleArn:    r.PostForm.Get("RoleArn"),
+			externalID: r.PostForm.Get("ExternalId"),
+			duration:   r.PostForm.Get("DurationSeconds"),
+		}
+		mu.Lock()
+		calls = append(calls, call)
+		mu.Unlock()
+
+		expiration := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+		fmt.Fprintf(w, assumeRoleResponse, "ak-"+call.roleArn[strings.LastIndex(call.roleArn, "/")+1:], expiration)
+	}))
+	defer srv.Close()
+
+	base := aws.Config{
+		Region: TestRegion,
+		EndpointResolverWithOptions: aws.EndpointResolverWithOptionsFunc(func(service, region string, _ ...interface{}) (aws.Endpoint, error) {
+			return aws.Endpoint{URL: srv.URL}, nil
+		}),
+	}
+	source := NewRoleChainCredentials(
+		NewStaticCredentials(TestAccessKey, TestSecretAccessKey),
+		AssumeRole{RoleArn: "arn:aws:iam::111111111111:role/hub", ExternalID: "ext"},
+		AssumeRole{RoleArn: "arn:aws:iam::222222222222:role/workload", Duration: 30 * time.Minute},
+	)
+	provider, err := source.Resolve(context.TODO(), base)
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	creds, err := provider.Retrieve(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if creds.AccessKeyID != "ak-workload" {
+		t.Fatalf("unexpected credentials %#v\n", creds)
+	}
+
+	expected := []assumeRoleCall{
+		{accessKey: TestAccessKey, roleArn: "arn:aws:iam::111111111111:role/hub", externalID: "ext", duration: "900"},
+		{accessKey: "ak-hub", roleArn: "arn:aws:iam::222222222222:role/workload", duration: "1800"},
+	}
+	if len(calls) != len(expected) {
+		t.Fatalf("expected %d calls, got %#v\n", len(expected), calls)
+	}
+	for i := range expected {
+		if calls[i] != expected[i] {
+			t.Fatalf("call %d: expected %#v, got %#v\n", i, expected[i], calls[i])
+		}
+	}
+
+	// Cached credentials are not refreshed before they expire.
+	if _, err := provider.Retrieve(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(calls) != len(expected) {
+		t.Fatalf("expected cached credentials, got %d calls\n", len(calls))
+	}
+}
+
+func signingAccessKey(r *http.Request) string {
+	auth := r.Header.Get("Authorization")
+	i := strings.Index(auth, "Credential=")
+	if i < 0 {
+		return ""
+	}
+	cred := auth[i+len("Credential="):]
+	return cred[:strings.Index(cred, "/")]
+}
@@ -16,22 +16,25 @@ package aws
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awscfg "github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/credentials"
 )
 
 type Sessions map[string]aws.Config
 
 type awsCreds struct {
 	credentials []credential
+	endpoint    endpointOptions
+	retry       retryOptions
 }
 
 type credential struct {
-	region          string
-	accessKey       string
-	secretAccessKey string
+	account string
+	region  string
+	source  CredentialSource
 }
 
 func NewSessions() *awsCreds {
@@ -39,34 +42,108 @@ func NewSessions() *awsCreds {
 }
 
 func (s *awsCreds) SetCredential(region, accessKey, secretAccessKey string) *awsCreds {
-	s.credentials = append(s.credentials, credential{
-		region:          region,
-		accessKey:       accessKey,
-		secretAccessKey: secretAccessKey,
-	})
-	return s
+	return s.SetCredentialSource(region, NewStaticCredentials(accessKey, secretAccessKey))
 }
 
+// SetCredentialSource registers a session for region whose credentials come
+// from source, e.g. a shared profile, web identity or an assumed role.
+func (s *awsCreds) SetCredentialSource(region string, source CredentialSource) *awsCreds {
+	return s.SetAccountCredentialSource(DefaultAccount, region, source)
+}
+
+// Get returns the session of region, or ErrSessionNotFound if no session
+// was built for it.
+func (s Sessions) Get(region string) (aws.Config, error) {
+	cfg, ok := s[region]
+	if !ok {
+		return aws.Config{}, fmt.Errorf("region %s: %w", region, ErrSessionNotFound)
+	}
+	return cfg, nil
+}
+
+// Build skips the credentials whose session could not be built. Use
+// BuildStrict to find out which ones failed.
 func (s *awsCreds) Build() Sessions {
+	sess, _ := s.build()
+	return sess.sessions()
+}
+
+// BuildStrict builds every valid session and returns a *BuildError that
+// describes each credential entry which failed.
+func (s *awsCreds) BuildStrict() (Sessions, error) {
+	sess, errs := s.build()
+	if len(errs) > 0 {
+		return sess.sessions(), &BuildError{Errors: errs}
+	}
+	return sess.sessions(), nil
+}
+
+type builtSession struct {
+	key SessionKey
+	cfg aws.Config
+}
+
+type builtSessions []builtSession
+
+// sessions keys the built sessions by region only, so a later entry wins
+// over an earlier one of another account in the same region.
+func (b builtSessions) sessions() Sessions {
 	sess := map[string]aws.Config{}
-	for _, v := range s.credentials {
-		as, err := newAWSSession(v.region, v.accessKey, v.secretAccessKey)
+	for _, v := range b {
+		sess[v.key.Region] = v.cfg
+	}
+	return sess
+}
+
+func (b builtSessions) registry() Registry {
+	reg := Registry{}
+	for _, v := range b {
+		reg[v.key] = v.cfg
+	}
+	return reg
+}
+
+func (s *awsCreds) build() (builtSessions, []*CredentialError) {
+	sess := builtSessions{}
+	errs := []*CredentialError{}
+	for i, v := range s.credentials {
+		opts := append(s.endpoint.loadOptions(), s.retry.loadOptions()...)
+		as, err := newAWSSession(v.region, v.source, opts...)
 		if err != nil {
+			errs = append(errs, &CredentialError{Index: i, Account: v.account, Region: v.region, Err: err})
 			continue
 		}
-		sess[v.region] = as
+		sess = append(sess, builtSession{
+			key: SessionKey{Account: v.account, Region: v.region},
+			cfg: as,
+		})
 	}
-	return sess
+	return sess, errs
 }
 
-func newAWSSession(region, ak, sk string) (aws.Config, error) {
+func newAWSSession(region string, source CredentialSource, optFns ...func(*awscfg.LoadOptions) error) (aws.Config, error) {
+	if strings.EqualFold(region, "") {
+		return aws.Config{}, ErrEmptyRegion
+	}
+	if source == nil {
+		return aws.Config{}, ErrNoCredentials
+	}
+
+	ctx := context.Background()
 	opts := []func(*awscfg.LoadOptions) error{
 		awscfg.WithRegion(region),
+		awscfg.WithCredentialsProvider(aws.AnonymousCredentials{}),
+	}
+	opts = append(opts, optFns...)
+	cfg, err := awscfg.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return aws.Config{}, err
+	}
+
+	provider, err := source.Resolve(ctx, cfg)
+	if err != nil {
+		return aws.Config{}, err
 	}
-	opts = append(opts, awscfg.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
-		ak,
-		sk,
-		"",
-	)))
-	return awscfg.LoadDefaultConfig(context.Background(), opts...)
+	cfg.Credentials = provider
+	return cfg, nil
 }
@@ -0,0 +1,88 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func Test_BuildStrict(t *testing.T) {
+	clearEnvCredentials(t)
+	sess, err := NewSessions().
+		SetCredential(TestRegion, TestAccessKey, TestSecretAccessKey).
+		SetCredential("", TestAccessKey, TestSecretAccessKey).
+		SetCredentialSource("us-west-2", NewEnvCredentials()).
+		BuildStrict()
+
+	var berr *BuildError
+	if !errors.As(err, &berr) {
+		t.Fatalf("expected *BuildError, got %+v\n", err)
+	}
+	if len(berr.Errors) != 2 {
+		t.Fatalf("expected 2 failed entries, got %d\n", len(berr.Errors))
+	}
+	if berr.Errors[0].Index != 1 || !errors.Is(berr.Errors[0], ErrEmptyRegion) {
+		t.Fatalf("unexpected error %+v\n", berr.Errors[0])
+	}
+	if berr.Errors[1].Region != "us-west-2" || !errors.Is(berr.Errors[1], ErrNoCredentials) {
+		t.Fatalf("unexpected error %+v\n", berr.Errors[1])
+	}
+	if !berr.Is(ErrEmptyRegion) || !berr.Is(ErrNoCredentials) || berr.Is(ErrSessionNotFound) {
+		t.Fatalf("unexpected Is of %+v\n", err)
+	}
+	var cerr *CredentialError
+	if !berr.As(&cerr) || cerr.Index != 1 {
+		t.Fatalf("unexpected As of %+v\n", err)
+	}
+
+	if _, err := sess.Get(TestRegion); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if _, err := sess.Get("us-west-2"); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound, got %+v\n", err)
+	}
+}
+
+func Test_BuildRegistry(t *testing.T) {
+	reg := NewSessions().
+		SetAccountCredential("111111111111", TestRegion, "ak-1", TestSecretAccessKey).
+		SetAccountCredential("222222222222", TestRegion, "ak-2", TestSecretAccessKey).
+		BuildRegistry()
+
+	if len(reg) != 2 {
+		t.Fatalf("expected 2 sessions, got %d\n", len(reg))
+	}
+
+	cfg, err := reg.GetByArn("arn:aws:rds:us-east-1:222222222222:db:foo")
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	creds, err := cfg.Credentials.Retrieve(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if creds.AccessKeyID != "ak-2" {
+		t.Fatalf("unexpected credentials %#v\n", creds)
+	}
+
+	if _, err := reg.Get(DefaultAccount, TestRegion); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound, got %+v\n", err)
+	}
+	if len(reg.Sessions("111111111111")) != 1 {
+		t.Fatalf("expected 1 session for account\n")
+	}
+}
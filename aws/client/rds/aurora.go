@@ -19,6 +19,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
 )
 
 type Aurora interface {
@@ -31,23 +32,63 @@ type Aurora interface {
 	SetVpcSecurityGroupIds(sgids []string) Aurora
 	SetDBSubnetGroup(sbg string) Aurora
 	SetSkipFinalSnapshot(enable bool) Aurora
+	SetDBClusterSnapshotIdentifier(id string) Aurora
+	SetSourceDBClusterSnapshotIdentifier(id string) Aurora
+	SetSourceRegion(region string) Aurora
+	SetKmsKeyId(id string) Aurora
+	SetEngineMode(mode string) Aurora
+	SetServerlessV2Scaling(min, max float64) Aurora
+	SetServerlessV1Scaling(scaling ServerlessV1Scaling) Aurora
+	SetDBClusterEndpointIdentifier(id string) Aurora
+	SetEndpointType(t string) Aurora
+	SetStaticMembers(ids []string) Aurora
+	SetExcludedMembers(ids []string) Aurora
+	SetFailoverPolicy(policy FailoverPolicy) Aurora
+	SetDBClusterParameterGroupName(name string) Aurora
+	SetTags(tags map[string]string) Aurora
 
 	// RDSInstance for Aurora
 	SetDBInstanceIdentifier(id string) Aurora
 	SetDBInstanceClass(class string) Aurora
+	SetDBParameterGroupName(name string) Aurora
 	SetPublicAccessible(enable bool) Aurora
 	SetDeleteAutomateBackups(enable bool) Aurora
 
 	Create(context.Context) error
 	CreateWithPrimary(context.Context) error
 	FailoverPrimary(context.Context) error
-	FailoverRandomOneReadonlyEndpoint(context.Context) error
+	FailoverRandomOneReadonlyEndpoint(context.Context) (string, error)
 	NewReadonlyEndpoint(context.Context) error
+	ModifyCustomEndpoint(context.Context) error
+	DeleteCustomEndpoint(context.Context) error
+	ListCustomEndpoints(context.Context) ([]*DescClusterEndpoint, error)
+	AddReaders(context.Context, ...ReaderSpec) error
+	RemoveReaders(context.Context, ...string) error
+	ScaleReaders(context.Context, int) error
+	ModifyScaling(context.Context) error
+	Stop(context.Context) error
+	Start(context.Context) error
+	Events(context.Context, *EventFilter) ([]*Event, error)
 	Delete(context.Context) error
+	Describe(context.Context) (*DescCluster, error)
+	CreateSnapshot(context.Context) error
+	DescribeSnapshot(context.Context) (*DescClusterSnapshot, error)
+	ListSnapshots(context.Context) ([]*DescClusterSnapshot, error)
+	CopySnapshot(context.Context) error
+	DeleteSnapshot(context.Context) error
+	RestoreFromSnapshot(context.Context) error
+	List(context.Context, *ListFilter) ([]*DescCluster, error)
+
+	WaitUntilAvailable(context.Context, ...WaitOption) error
+	WaitUntilDeleted(context.Context, ...WaitOption) error
+	WaitUntilFailoverComplete(context.Context, ...WaitOption) error
+	WaitUntilSnapshotAvailable(context.Context, ...WaitOption) error
+	WaitUntilCustomEndpointAvailable(context.Context, ...WaitOption) error
+	WaitUntilStopped(context.Context, ...WaitOption) error
 }
 
 type rdsAurora struct {
-	core *rds.Client
+	core API
 
 	createClusterParam         *rds.CreateDBClusterInput
 	deleteClusterParam         *rds.DeleteDBClusterInput
@@ -55,25 +96,69 @@ type rdsAurora struct {
 	failoverGlobalClusterParam *rds.FailoverGlobalClusterInput
 	rebootClusterParam         *rds.RebootDBClusterInput
 	describeClusterParam       *rds.DescribeDBClustersInput
+	modifyClusterParam         *rds.ModifyDBClusterInput
 	restoreDBClusterPitrParam  *rds.RestoreDBClusterToPointInTimeInput
+	stopClusterParam           *rds.StopDBClusterInput
+	startClusterParam          *rds.StartDBClusterInput
 
 	createInstanceParam      *rds.CreateDBInstanceInput
 	deleteInstanceParam      *rds.DeleteDBInstanceInput
 	rebootInstanceParam      *rds.RebootDBInstanceInput
 	describeInstanceParam    *rds.DescribeDBInstancesInput
 	restoreInstancePitrParam *rds.RestoreDBInstanceToPointInTimeInput
+
+	createEndpointParam   *rds.CreateDBClusterEndpointInput
+	modifyEndpointParam   *rds.ModifyDBClusterEndpointInput
+	deleteEndpointParam   *rds.DeleteDBClusterEndpointInput
+	describeEndpointParam *rds.DescribeDBClusterEndpointsInput
+	failoverPolicy        FailoverPolicy
+	// failoverTarget is the reader picked by the last
+	// FailoverRandomOneReadonlyEndpoint, see WaitUntilFailoverComplete.
+	failoverTarget *string
+	// failoverFrom is the writer before the last FailoverPrimary.
+	failoverFrom string
+
+	snapshot *clusterSnapshotParams
 }
 
 var _ Aurora = &rdsAurora{}
 
+func newAurora(core API) *rdsAurora {
+	return &rdsAurora{
+		core:                       core,
+		createClusterParam:         &rds.CreateDBClusterInput{},
+		deleteClusterParam:         &rds.DeleteDBClusterInput{},
+		failoverClusterParam:       &rds.FailoverDBClusterInput{},
+		failoverGlobalClusterParam: &rds.FailoverGlobalClusterInput{},
+		rebootClusterParam:         &rds.RebootDBClusterInput{},
+		describeClusterParam:       &rds.DescribeDBClustersInput{},
+		modifyClusterParam:         &rds.ModifyDBClusterInput{},
+		restoreDBClusterPitrParam:  &rds.RestoreDBClusterToPointInTimeInput{},
+		stopClusterParam:           &rds.StopDBClusterInput{},
+		startClusterParam:          &rds.StartDBClusterInput{},
+		createInstanceParam:        &rds.CreateDBInstanceInput{},
+		deleteInstanceParam:        &rds.DeleteDBInstanceInput{},
+		rebootInstanceParam:        &rds.RebootDBInstanceInput{},
+		describeInstanceParam:      &rds.DescribeDBInstancesInput{},
+		restoreInstancePitrParam:   &rds.RestoreDBInstanceToPointInTimeInput{},
+		createEndpointParam:        &rds.CreateDBClusterEndpointInput{},
+		modifyEndpointParam:        &rds.ModifyDBClusterEndpointInput{},
+		deleteEndpointParam:        &rds.DeleteDBClusterEndpointInput{},
+		describeEndpointParam:      &rds.DescribeDBClusterEndpointsInput{},
+		snapshot:                   newClusterSnapshotParams(),
+	}
+}
+
 func (s *rdsAurora) SetEngine(engine string) Aurora {
 	s.createClusterParam.Engine = aws.String(engine)
 	s.createInstanceParam.Engine = aws.String(engine)
+	s.snapshot.restoreParam.Engine = aws.String(engine)
 	return s
 }
 
 func (s *rdsAurora) SetEngineVersion(version string) Aurora {
 	s.createClusterParam.EngineVersion = aws.String(version)
+	s.snapshot.restoreParam.EngineVersion = aws.String(version)
 	return s
 }
 
@@ -82,16 +167,23 @@ func (s *rdsAurora) SetDBClusterIdentifier(id string) Aurora {
 	s.createInstanceParam.DBClusterIdentifier = aws.String(id)
 	s.failoverClusterParam.DBClusterIdentifier = aws.String(id)
 	s.deleteClusterParam.DBClusterIdentifier = aws.String(id)
+	s.describeClusterParam.DBClusterIdentifier = aws.String(id)
+	s.modifyClusterParam.DBClusterIdentifier = aws.String(id)
+	s.stopClusterParam.DBClusterIdentifier = aws.String(id)
+	s.startClusterParam.DBClusterIdentifier = aws.String(id)
+	s.snapshot.setDBClusterIdentifier(id)
 	return s
 }
 
 func (s *rdsAurora) SetVpcSecurityGroupIds(ids []string) Aurora {
 	s.createClusterParam.VpcSecurityGroupIds = ids
+	s.snapshot.restoreParam.VpcSecurityGroupIds = ids
 	return s
 }
 
 func (s *rdsAurora) SetDBSubnetGroup(sbg string) Aurora {
 	s.createClusterParam.DBSubnetGroupName = aws.String(sbg)
+	s.snapshot.restoreParam.DBSubnetGroupName = aws.String(sbg)
 	return s
 }
 
@@ -106,6 +198,33 @@ func (s *rdsAurora) SetDBInstanceClass(class string) Aurora {
 	return s
 }
 
+// SetDBParameterGroupName sets the DB parameter group of the instances,
+// see SetDBClusterParameterGroupName for the cluster-wide parameters.
+func (s *rdsAurora) SetDBParameterGroupName(name string) Aurora {
+	s.createInstanceParam.DBParameterGroupName = aws.String(name)
+	return s
+}
+
+// SetTags sets the tags of everything the builder creates: the cluster, its
+// instances, custom endpoints and cluster snapshots. Tags set several times
+// are merged.
+func (s *rdsAurora) SetTags(tags map[string]string) Aurora {
+	s.createClusterParam.Tags = mergeTags(s.createClusterParam.Tags, tags)
+	s.restoreDBClusterPitrParam.Tags = mergeTags(s.restoreDBClusterPitrParam.Tags, tags)
+	s.createInstanceParam.Tags = mergeTags(s.createInstanceParam.Tags, tags)
+	s.restoreInstancePitrParam.Tags = mergeTags(s.restoreInstancePitrParam.Tags, tags)
+	s.createEndpointParam.Tags = mergeTags(s.createEndpointParam.Tags, tags)
+	s.snapshot.setTags(tags)
+	return s
+}
+
+func (s *rdsAurora) SetDBClusterParameterGroupName(name string) Aurora {
+	s.createClusterParam.DBClusterParameterGroupName = aws.String(name)
+	s.modifyClusterParam.DBClusterParameterGroupName = aws.String(name)
+	s.snapshot.restoreParam.DBClusterParameterGroupName = aws.String(name)
+	return s
+}
+
 func (s *rdsAurora) SetPublicAccessible(enable bool) Aurora {
 	s.createInstanceParam.PubliclyAccessible = aws.Bool(enable)
 	return s
@@ -142,22 +261,22 @@ func (s *rdsAurora) CreateWithPrimary(ctx context.Context) error {
 		return err
 	}
 
-	if _, err := s.core.CreateDBInstance(ctx, s.createInstanceParam); err != nil {
+	if _, err := s.core.CreateDBInstance(ctx, s.primaryInstanceParam()); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (s *rdsAurora) NewReadonlyEndpoint(ctx context.Context) error {
-	return nil
-}
-
 func (s *rdsAurora) FailoverPrimary(ctx context.Context) error {
-	_, err := s.core.FailoverDBCluster(ctx, s.failoverClusterParam)
-	return err
-}
-
-func (s *rdsAurora) FailoverRandomOneReadonlyEndpoint(ctx context.Context) error {
+	desc, err := describeCluster(ctx, s.core, s.describeClusterParam)
+	if err != nil {
+		return err
+	}
+	if _, err := s.core.FailoverDBCluster(ctx, s.failoverClusterParam); err != nil {
+		return err
+	}
+	s.failoverTarget = nil
+	s.failoverFrom = desc.Writer()
 	return nil
 }
 
@@ -172,3 +291,27 @@ func (s *rdsAurora) Delete(ctx context.Context) error {
 
 	return nil
 }
+
+func (s *rdsAurora) Describe(ctx context.Context) (*DescCluster, error) {
+	return describeCluster(ctx, s.core, s.describeClusterParam)
+}
+
+// describeClusterInstances returns the instances of the cluster, following
+// pagination.
+func describeClusterInstances(ctx context.Context, core API, id string) ([]types.DBInstance, error) {
+	paginator := rds.NewDescribeDBInstancesPaginator(core, &rds.DescribeDBInstancesInput{
+		Filters: []types.Filter{
+			{Name: aws.String("db-cluster-id"), Values: []string{id}},
+		},
+	})
+
+	instances := []types.DBInstance{}
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		instances = append(instances, output.DBInstances...)
+	}
+	return instances, nil
+}
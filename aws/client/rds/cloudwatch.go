@@ -0,0 +1,100 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	dbmesh "github.com/database-mesh/golang-sdk/aws"
+)
+
+const (
+	// replicationLagMetric is reported by the secondary clusters of Aurora
+	// global databases, in milliseconds.
+	replicationLagMetric = "AuroraGlobalDBReplicationLag"
+	// replicationLagWindow is how far back datapoints are looked for.
+	replicationLagWindow = 5 * time.Minute
+)
+
+var ErrNoLagDatapoints = errors.New("no replication lag datapoints")
+
+// CloudWatchAPI is the part of the CloudWatch API used by the lag source.
+type CloudWatchAPI interface {
+	GetMetricStatistics(ctx context.Context, params *cloudwatch.GetMetricStatisticsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.GetMetricStatisticsOutput, error)
+}
+
+var _ CloudWatchAPI = &cloudwatch.Client{}
+
+type cloudWatchLag struct {
+	apis map[string]CloudWatchAPI
+}
+
+// NewCloudWatchLagSource returns a LagSource reading the
+// AuroraGlobalDBReplicationLag metric of the secondary clusters from the
+// CloudWatch of their region. The clients are built from the sessions, so
+// they share their endpoints, retries and rate limits.
+func NewCloudWatchLagSource(sess dbmesh.Sessions) LagSource {
+	apis := map[string]CloudWatchAPI{}
+	for region, cfg := range sess {
+		apis[region] = cloudwatch.NewFromConfig(cfg)
+	}
+	return NewCloudWatchLagSourceWithAPI(apis)
+}
+
+// NewCloudWatchLagSourceWithAPI returns a LagSource over the CloudWatch APIs
+// of each region.
+func NewCloudWatchLagSourceWithAPI(apis map[string]CloudWatchAPI) LagSource {
+	return &cloudWatchLag{apis: apis}
+}
+
+// ReplicationLag returns the latest average lag of the last minutes.
+func (c *cloudWatchLag) ReplicationLag(ctx context.Context, region, id string) (time.Duration, error) {
+	api, ok := c.apis[region]
+	if !ok {
+		return 0, fmt.Errorf("region %s: %w", region, dbmesh.ErrSessionNotFound)
+	}
+
+	end := time.Now().UTC()
+	output, err := api.GetMetricStatistics(ctx, &cloudwatch.GetMetricStatisticsInput{
+		Namespace:  aws.String("AWS/RDS"),
+		MetricName: aws.String(replicationLagMetric),
+		Dimensions: []types.Dimension{
+			{Name: aws.String("DBClusterIdentifier"), Value: aws.String(id)},
+		},
+		StartTime:  aws.Time(end.Add(-replicationLagWindow)),
+		EndTime:    aws.Time(end),
+		Period:     aws.Int32(60),
+		Statistics: []types.Statistic{types.StatisticAverage},
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(output.Datapoints) == 0 {
+		return 0, ErrNoLagDatapoints
+	}
+	latest := output.Datapoints[0]
+	for _, p := range output.Datapoints[1:] {
+		if aws.ToTime(p.Timestamp).After(aws.ToTime(latest.Timestamp)) {
+			latest = p
+		}
+	}
+	return time.Duration(aws.ToFloat64(latest.Average) * float64(time.Millisecond)), nil
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
 )
 
 type Cluster interface {
@@ -35,6 +36,8 @@ type Cluster interface {
 	SetDatabaseName(name string) Cluster
 	SetEngineVersion(version string) Cluster
 	SetEngineMode(mode string) Cluster
+	SetServerlessV2Scaling(min, max float64) Cluster
+	SetServerlessV1Scaling(scaling ServerlessV1Scaling) Cluster
 	SetMasterUsername(username string) Cluster
 	SetMasterUserPassword(pass string) Cluster
 	SetVpcSecurityGroupIds(sgs []string) Cluster
@@ -47,6 +50,16 @@ type Cluster interface {
 	SetRestoreType(t string) Cluster
 	SetUseLatestRestorableTime(enable bool) Cluster
 	SetPublicAccessible(enable bool) Cluster
+	SetBackupRetentionPeriod(days int32) Cluster
+	SetDBClusterParameterGroupName(name string) Cluster
+	SetTags(tags map[string]string) Cluster
+	SetDeletionProtection(enable bool) Cluster
+	SetAllowMajorVersionUpgrade(enable bool) Cluster
+	SetApplyImmediately(enable bool) Cluster
+	SetDBClusterSnapshotIdentifier(id string) Cluster
+	SetSourceDBClusterSnapshotIdentifier(id string) Cluster
+	SetSourceRegion(region string) Cluster
+	SetKmsKeyId(id string) Cluster
 
 	Failover(context.Context) error
 	FailoverGlobal(context.Context) error
@@ -55,10 +68,28 @@ type Cluster interface {
 	Reboot(context.Context) error
 	Describe(context.Context) (*DescCluster, error)
 	RestorePitr(context.Context) error
+	Modify(context.Context) error
+	Stop(context.Context) error
+	Start(context.Context) error
+	Events(context.Context, *EventFilter) ([]*Event, error)
+	CreateSnapshot(context.Context) error
+	DescribeSnapshot(context.Context) (*DescClusterSnapshot, error)
+	ListSnapshots(context.Context) ([]*DescClusterSnapshot, error)
+	CopySnapshot(context.Context) error
+	DeleteSnapshot(context.Context) error
+	RestoreFromSnapshot(context.Context) error
+	List(context.Context, *ListFilter) ([]*DescCluster, error)
+
+	WaitUntilAvailable(context.Context, ...WaitOption) error
+	WaitUntilDeleted(context.Context, ...WaitOption) error
+	WaitUntilFailoverComplete(context.Context, ...WaitOption) error
+	WaitUntilModified(context.Context, ...WaitOption) error
+	WaitUntilSnapshotAvailable(context.Context, ...WaitOption) error
+	WaitUntilStopped(context.Context, ...WaitOption) error
 }
 
 type rdsCluster struct {
-	core                       *rds.Client
+	core                       API
 	createClusterParam         *rds.CreateDBClusterInput
 	deleteClusterParam         *rds.DeleteDBClusterInput
 	failoverClusterParam       *rds.FailoverDBClusterInput
@@ -66,6 +97,30 @@ type rdsCluster struct {
 	rebootClusterParam         *rds.RebootDBClusterInput
 	describeClusterParam       *rds.DescribeDBClustersInput
 	restoreDBClusterPitrParam  *rds.RestoreDBClusterToPointInTimeInput
+	modifyClusterParam         *rds.ModifyDBClusterInput
+	stopClusterParam           *rds.StopDBClusterInput
+	startClusterParam          *rds.StartDBClusterInput
+	snapshot                   *clusterSnapshotParams
+	// failoverFrom is the writer before the last Failover, see
+	// WaitUntilFailoverComplete.
+	failoverFrom string
+}
+
+func newCluster(core API) *rdsCluster {
+	return &rdsCluster{
+		core:                       core,
+		createClusterParam:         &rds.CreateDBClusterInput{},
+		deleteClusterParam:         &rds.DeleteDBClusterInput{},
+		failoverClusterParam:       &rds.FailoverDBClusterInput{},
+		failoverGlobalClusterParam: &rds.FailoverGlobalClusterInput{},
+		rebootClusterParam:         &rds.RebootDBClusterInput{},
+		describeClusterParam:       &rds.DescribeDBClustersInput{},
+		restoreDBClusterPitrParam:  &rds.RestoreDBClusterToPointInTimeInput{},
+		modifyClusterParam:         &rds.ModifyDBClusterInput{},
+		stopClusterParam:           &rds.StopDBClusterInput{},
+		startClusterParam:          &rds.StartDBClusterInput{},
+		snapshot:                   newClusterSnapshotParams(),
+	}
 }
 
 // FailoverClusterInput
@@ -76,6 +131,10 @@ func (s *rdsCluster) SetDBClusterIdentifier(id string) Cluster {
 	s.rebootClusterParam.DBClusterIdentifier = aws.String(id)
 	s.describeClusterParam.DBClusterIdentifier = aws.String(id)
 	s.restoreDBClusterPitrParam.DBClusterIdentifier = aws.String(id)
+	s.modifyClusterParam.DBClusterIdentifier = aws.String(id)
+	s.stopClusterParam.DBClusterIdentifier = aws.String(id)
+	s.startClusterParam.DBClusterIdentifier = aws.String(id)
+	s.snapshot.setDBClusterIdentifier(id)
 	return s
 }
 
@@ -85,12 +144,21 @@ func (s *rdsCluster) SetTargetDBInstanceIdentifier(id string) Cluster {
 }
 
 func (s *rdsCluster) Failover(ctx context.Context) error {
-	_, err := s.core.FailoverDBCluster(ctx, s.failoverClusterParam)
-	return err
+	desc, err := describeCluster(ctx, s.core, s.describeClusterParam)
+	if err != nil {
+		return err
+	}
+	if _, err := s.core.FailoverDBCluster(ctx, s.failoverClusterParam); err != nil {
+		return err
+	}
+	s.failoverFrom = desc.Writer()
+	return nil
 }
 
 // FailoverGlobalClusterInput
+// NOTE: Create joins the global cluster as a secondary, see GlobalDatabase.
 func (s *rdsCluster) SetGlobalClusterIdentifier(id string) Cluster {
+	s.createClusterParam.GlobalClusterIdentifier = aws.String(id)
 	s.failoverGlobalClusterParam.GlobalClusterIdentifier = aws.String(id)
 	return s
 }
@@ -108,43 +176,53 @@ func (s *rdsCluster) FailoverGlobal(ctx context.Context) error {
 // CreateDBClusterInput
 func (s *rdsCluster) SetEngine(engine string) Cluster {
 	s.createClusterParam.Engine = aws.String(engine)
+	s.snapshot.restoreParam.Engine = aws.String(engine)
 	return s
 }
 
 func (s *rdsCluster) SetAllocatedStorage(size int32) Cluster {
 	s.createClusterParam.AllocatedStorage = aws.Int32(size)
+	s.modifyClusterParam.AllocatedStorage = aws.Int32(size)
 	return s
 }
 
 func (s *rdsCluster) SetAvailabilityZones(azs []string) Cluster {
 	s.createClusterParam.AvailabilityZones = azs
+	s.snapshot.restoreParam.AvailabilityZones = azs
 	return s
 }
 
 func (s *rdsCluster) SetDBClusterInstanceClass(class string) Cluster {
 	s.createClusterParam.DBClusterInstanceClass = aws.String(class)
 	s.restoreDBClusterPitrParam.DBClusterInstanceClass = aws.String(class)
+	s.modifyClusterParam.DBClusterInstanceClass = aws.String(class)
+	s.snapshot.restoreParam.DBClusterInstanceClass = aws.String(class)
 	return s
 }
 
 func (s *rdsCluster) SetDBSubnetGroupName(name string) Cluster {
 	s.createClusterParam.DBSubnetGroupName = aws.String(name)
 	s.restoreDBClusterPitrParam.DBSubnetGroupName = aws.String(name)
+	s.snapshot.restoreParam.DBSubnetGroupName = aws.String(name)
 	return s
 }
 
 func (s *rdsCluster) SetDatabaseName(name string) Cluster {
 	s.createClusterParam.DatabaseName = aws.String(name)
+	s.snapshot.restoreParam.DatabaseName = aws.String(name)
 	return s
 }
 
 func (s *rdsCluster) SetEngineVersion(version string) Cluster {
 	s.createClusterParam.EngineVersion = aws.String(version)
+	s.modifyClusterParam.EngineVersion = aws.String(version)
+	s.snapshot.restoreParam.EngineVersion = aws.String(version)
 	return s
 }
 
 func (s *rdsCluster) SetEngineMode(mode string) Cluster {
 	s.createClusterParam.EngineMode = aws.String(mode)
+	s.snapshot.restoreParam.EngineMode = aws.String(mode)
 	return s
 }
 
@@ -160,17 +238,23 @@ func (s *rdsCluster) SetMasterUserPassword(pass string) Cluster {
 
 func (s *rdsCluster) SetVpcSecurityGroupIds(sgs []string) Cluster {
 	s.createClusterParam.VpcSecurityGroupIds = sgs
+	s.snapshot.restoreParam.VpcSecurityGroupIds = sgs
 	return s
 }
 
 func (s *rdsCluster) SetStorageType(t string) Cluster {
 	s.createClusterParam.StorageType = aws.String(t)
+	s.restoreDBClusterPitrParam.StorageType = aws.String(t)
+	s.modifyClusterParam.StorageType = aws.String(t)
+	s.snapshot.restoreParam.StorageType = aws.String(t)
 	return s
 }
 
 func (s *rdsCluster) SetIOPS(ps int32) Cluster {
 	s.createClusterParam.Iops = aws.Int32(ps)
 	s.restoreDBClusterPitrParam.Iops = aws.Int32(ps)
+	s.modifyClusterParam.Iops = aws.Int32(ps)
+	s.snapshot.restoreParam.Iops = aws.Int32(ps)
 	return s
 }
 
@@ -187,6 +271,7 @@ func (s *rdsCluster) SetSkipFinalSnapshot(skip bool) Cluster {
 
 func (s *rdsCluster) SetPublicAccessible(enable bool) Cluster {
 	s.createClusterParam.PubliclyAccessible = aws.Bool(enable)
+	s.snapshot.restoreParam.PubliclyAccessible = aws.Bool(enable)
 	return s
 }
 
@@ -247,42 +332,155 @@ type DescCluster struct {
 	ReplicationSourceIdentifier string
 	Status                      string
 	Port                        int32
+	Engine                      string
+	EngineVersion               string
+	EngineMode                  string
+	Tags                        map[string]string
+	// PendingModifications reports changes waiting to be applied, e.g. in
+	// the next maintenance window.
+	PendingModifications bool
+	// ServerlessV2Scaling is set on Aurora Serverless v2 clusters.
+	ServerlessV2Scaling *ServerlessV2Scaling
+	// ServerlessV1Scaling and Capacity are set on Aurora Serverless v1
+	// clusters, Capacity is 0 while paused.
+	ServerlessV1Scaling *ServerlessV1Scaling
+	Capacity            int32
+	// AutomaticRestartTime is when RDS starts the stopped cluster again,
+	// see AutoRestartAfter.
+	AutomaticRestartTime time.Time
 }
 
 type ClusterMember struct {
 	DBClusterParameterGroupStatus string
 	DBInstanceIdentifier          string
 	IsClusterWrite                bool
+	PromotionTier                 int32
 }
 
 func (s *rdsCluster) Describe(ctx context.Context) (*DescCluster, error) {
-	output, err := s.core.DescribeDBClusters(ctx, s.describeClusterParam)
+	return describeCluster(ctx, s.core, s.describeClusterParam)
+}
+
+func describeCluster(ctx context.Context, core API, param *rds.DescribeDBClustersInput) (*DescCluster, error) {
+	output, err := core.DescribeDBClusters(ctx, param)
 	if err != nil {
 		return nil, err
 	}
 	desc := &DescCluster{}
 	if len(output.DBClusters) > 0 {
-		desc.AvailabilityZones = output.DBClusters[0].AvailabilityZones
-		desc.CharSetName = aws.ToString(output.DBClusters[0].CharacterSetName)
-		desc.ClusterCreateTime = aws.ToTime(output.DBClusters[0].ClusterCreateTime)
-		desc.CustomEndpoints = output.DBClusters[0].CustomEndpoints
-		desc.DBClusterArn = aws.ToString(output.DBClusters[0].DBClusterArn)
-		desc.DBClusterIdentifier = aws.ToString(output.DBClusters[0].DBClusterIdentifier)
-		for _, m := range output.DBClusters[0].DBClusterMembers {
-			desc.DBClusterMembers = append(desc.DBClusterMembers, ClusterMember{
-				DBClusterParameterGroupStatus: aws.ToString(m.DBClusterParameterGroupStatus),
-				DBInstanceIdentifier:          aws.ToString(m.DBInstanceIdentifier),
-				IsClusterWrite:                m.IsClusterWriter,
-			})
-		}
-		desc.DBClusterParamterGroup = aws.ToString(output.DBClusters[0].DBClusterParameterGroup)
-		desc.DeletionProtection = aws.ToBool(output.DBClusters[0].DeletionProtection)
-		desc.PrimaryEndpoint = aws.ToString(output.DBClusters[0].Endpoint)
-		desc.ReadReplicaIdentifiers = output.DBClusters[0].ReadReplicaIdentifiers
-		desc.ReaderEndpoint = aws.ToString(output.DBClusters[0].ReaderEndpoint)
-		desc.ReplicationSourceIdentifier = aws.ToString(output.DBClusters[0].ReplicationSourceIdentifier)
-		desc.Port = aws.ToInt32(output.DBClusters[0].Port)
-		desc.Status = aws.ToString(output.DBClusters[0].Status)
+		desc = convertDBCluster(output.DBClusters[0])
 	}
 	return desc, nil
 }
+
+func convertDBCluster(cluster types.DBCluster) *DescCluster {
+	desc := &DescCluster{}
+	desc.AvailabilityZones = cluster.AvailabilityZones
+	desc.CharSetName = aws.ToString(cluster.CharacterSetName)
+	desc.ClusterCreateTime = aws.ToTime(cluster.ClusterCreateTime)
+	desc.CustomEndpoints = cluster.CustomEndpoints
+	desc.DBClusterArn = aws.ToString(cluster.DBClusterArn)
+	desc.DBClusterIdentifier = aws.ToString(cluster.DBClusterIdentifier)
+	for _, m := range cluster.DBClusterMembers {
+		desc.DBClusterMembers = append(desc.DBClusterMembers, ClusterMember{
+			DBClusterParameterGroupStatus: aws.ToString(m.DBClusterParameterGroupStatus),
+			DBInstanceIdentifier:          aws.ToString(m.DBInstanceIdentifier),
+			IsClusterWrite:                m.IsClusterWriter,
+			PromotionTier:                 aws.ToInt32(m.PromotionTier),
+		})
+	}
+	desc.DBClusterParamterGroup = aws.ToString(cluster.DBClusterParameterGroup)
+	desc.DeletionProtection = aws.ToBool(cluster.DeletionProtection)
+	desc.PrimaryEndpoint = aws.ToString(cluster.Endpoint)
+	desc.ReadReplicaIdentifiers = cluster.ReadReplicaIdentifiers
+	desc.ReaderEndpoint = aws.ToString(cluster.ReaderEndpoint)
+	desc.ReplicationSourceIdentifier = aws.ToString(cluster.ReplicationSourceIdentifier)
+	desc.Port = aws.ToInt32(cluster.Port)
+	desc.Status = aws.ToString(cluster.Status)
+	desc.Engine = aws.ToString(cluster.Engine)
+	desc.EngineVersion = aws.ToString(cluster.EngineVersion)
+	desc.EngineMode = aws.ToString(cluster.EngineMode)
+	desc.Tags = convertTags(cluster.TagList)
+	desc.PendingModifications = hasClusterPendingModifiedValues(cluster.PendingModifiedValues)
+	convertServerlessScaling(desc, cluster)
+	desc.AutomaticRestartTime = aws.ToTime(cluster.AutomaticRestartTime)
+	return desc
+}
+
+// hasClusterPendingModifiedValues ignores the empty PendingModifiedValues
+// RDS may return once modifications are applied.
+func hasClusterPendingModifiedValues(v *types.ClusterPendingModifiedValues) bool {
+	if v == nil {
+		return false
+	}
+	return v.AllocatedStorage != nil ||
+		v.BackupRetentionPeriod != nil ||
+		v.DBClusterIdentifier != nil ||
+		v.EngineVersion != nil ||
+		v.IAMDatabaseAuthenticationEnabled != nil ||
+		v.Iops != nil ||
+		v.MasterUserPassword != nil ||
+		hasPendingCloudwatchLogsExports(v.PendingCloudwatchLogsExports)
+}
+
+// Writer returns the identifier of the writer instance, if any.
+func (d *DescCluster) Writer() string {
+	for _, m := range d.DBClusterMembers {
+		if m.IsClusterWrite {
+			return m.DBInstanceIdentifier
+		}
+	}
+	return ""
+}
+
+// ModifyDBClusterInput
+func (s *rdsCluster) SetBackupRetentionPeriod(days int32) Cluster {
+	s.createClusterParam.BackupRetentionPeriod = aws.Int32(days)
+	s.modifyClusterParam.BackupRetentionPeriod = aws.Int32(days)
+	return s
+}
+
+// SetTags sets the tags of the cluster and of the cluster snapshots the
+// builder creates. Tags set several times are merged.
+func (s *rdsCluster) SetTags(tags map[string]string) Cluster {
+	s.createClusterParam.Tags = mergeTags(s.createClusterParam.Tags, tags)
+	s.restoreDBClusterPitrParam.Tags = mergeTags(s.restoreDBClusterPitrParam.Tags, tags)
+	s.snapshot.setTags(tags)
+	return s
+}
+
+func (s *rdsCluster) SetDBClusterParameterGroupName(name string) Cluster {
+	s.createClusterParam.DBClusterParameterGroupName = aws.String(name)
+	s.restoreDBClusterPitrParam.DBClusterParameterGroupName = aws.String(name)
+	s.modifyClusterParam.DBClusterParameterGroupName = aws.String(name)
+	s.snapshot.restoreParam.DBClusterParameterGroupName = aws.String(name)
+	return s
+}
+
+func (s *rdsCluster) SetDeletionProtection(enable bool) Cluster {
+	s.createClusterParam.DeletionProtection = aws.Bool(enable)
+	s.restoreDBClusterPitrParam.DeletionProtection = aws.Bool(enable)
+	s.modifyClusterParam.DeletionProtection = aws.Bool(enable)
+	s.snapshot.restoreParam.DeletionProtection = aws.Bool(enable)
+	return s
+}
+
+// NOTE: Required by Modify to move to a new major engine version.
+func (s *rdsCluster) SetAllowMajorVersionUpgrade(enable bool) Cluster {
+	s.modifyClusterParam.AllowMajorVersionUpgrade = enable
+	return s
+}
+
+// SetApplyImmediately applies Modify right away instead of during the next
+// maintenance window.
+func (s *rdsCluster) SetApplyImmediately(enable bool) Cluster {
+	s.modifyClusterParam.ApplyImmediately = enable
+	return s
+}
+
+// Modify sends every modifiable field set on the builder, e.g. by
+// SetDBClusterInstanceClass or SetEngineVersion.
+func (s *rdsCluster) Modify(ctx context.Context) error {
+	_, err := s.core.ModifyDBCluster(ctx, s.modifyClusterParam)
+	return err
+}
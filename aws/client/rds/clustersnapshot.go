@@ -0,0 +1,300 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+// clusterSnapshotParams are the snapshot parameters shared by the Cluster
+// and Aurora builders.
+type clusterSnapshotParams struct {
+	createParam   *rds.CreateDBClusterSnapshotInput
+	describeParam *rds.DescribeDBClusterSnapshotsInput
+	copyParam     *rds.CopyDBClusterSnapshotInput
+	deleteParam   *rds.DeleteDBClusterSnapshotInput
+	restoreParam  *rds.RestoreDBClusterFromSnapshotInput
+}
+
+func newClusterSnapshotParams() *clusterSnapshotParams {
+	return &clusterSnapshotParams{
+		createParam:   &rds.CreateDBClusterSnapshotInput{},
+		describeParam: &rds.DescribeDBClusterSnapshotsInput{},
+		copyParam:     &rds.CopyDBClusterSnapshotInput{},
+		deleteParam:   &rds.DeleteDBClusterSnapshotInput{},
+		restoreParam:  &rds.RestoreDBClusterFromSnapshotInput{},
+	}
+}
+
+// setDBClusterIdentifier sets the cluster to snapshot on CreateSnapshot, the
+// cluster to list the snapshots of on ListSnapshots and the new cluster on
+// RestoreFromSnapshot.
+func (p *clusterSnapshotParams) setDBClusterIdentifier(id string) {
+	p.createParam.DBClusterIdentifier = aws.String(id)
+	p.describeParam.DBClusterIdentifier = aws.String(id)
+	p.restoreParam.DBClusterIdentifier = aws.String(id)
+}
+
+func (p *clusterSnapshotParams) setDBClusterSnapshotIdentifier(id string) {
+	p.createParam.DBClusterSnapshotIdentifier = aws.String(id)
+	p.describeParam.DBClusterSnapshotIdentifier = aws.String(id)
+	p.copyParam.TargetDBClusterSnapshotIdentifier = aws.String(id)
+	p.deleteParam.DBClusterSnapshotIdentifier = aws.String(id)
+	p.restoreParam.SnapshotIdentifier = aws.String(id)
+}
+
+// setTags sets the tags of the snapshots created by CreateSnapshot and
+// CopySnapshot and of the cluster created by RestoreFromSnapshot.
+func (p *clusterSnapshotParams) setTags(tags map[string]string) {
+	p.createParam.Tags = mergeTags(p.createParam.Tags, tags)
+	p.copyParam.Tags = mergeTags(p.copyParam.Tags, tags)
+	p.restoreParam.Tags = mergeTags(p.restoreParam.Tags, tags)
+}
+
+type DescClusterSnapshot struct {
+	DBClusterSnapshotIdentifier string
+	DBClusterSnapshotArn        string
+	DBClusterIdentifier         string
+	Engine                      string
+	EngineVersion               string
+	EngineMode                  string
+	Status                      string
+	SnapshotType                string
+	SnapshotCreateTime          time.Time
+	AllocatedStorage            int32
+	StorageEncrypted            bool
+	KmsKeyId                    string
+	PercentProgress             int32
+	SourceDBClusterSnapshotArn  string
+	Tags                        map[string]string
+}
+
+func convertDBClusterSnapshot(snapshot types.DBClusterSnapshot) *DescClusterSnapshot {
+	return &DescClusterSnapshot{
+		DBClusterSnapshotIdentifier: aws.ToString(snapshot.DBClusterSnapshotIdentifier),
+		DBClusterSnapshotArn:        aws.ToString(snapshot.DBClusterSnapshotArn),
+		DBClusterIdentifier:         aws.ToString(snapshot.DBClusterIdentifier),
+		Engine:                      aws.ToString(snapshot.Engine),
+		EngineVersion:               aws.ToString(snapshot.EngineVersion),
+		EngineMode:                  aws.ToString(snapshot.EngineMode),
+		Status:                      aws.ToString(snapshot.Status),
+		SnapshotType:                aws.ToString(snapshot.SnapshotType),
+		SnapshotCreateTime:          aws.ToTime(snapshot.SnapshotCreateTime),
+		AllocatedStorage:            snapshot.AllocatedStorage,
+		StorageEncrypted:            snapshot.StorageEncrypted,
+		KmsKeyId:                    aws.ToString(snapshot.KmsKeyId),
+		PercentProgress:             snapshot.PercentProgress,
+		SourceDBClusterSnapshotArn:  aws.ToString(snapshot.SourceDBClusterSnapshotArn),
+		Tags:                        convertTags(snapshot.TagList),
+	}
+}
+
+func (p *clusterSnapshotParams) create(ctx context.Context, core API) error {
+	_, err := core.CreateDBClusterSnapshot(ctx, p.createParam)
+	return err
+}
+
+func (p *clusterSnapshotParams) describe(ctx context.Context, core API) (*DescClusterSnapshot, error) {
+	output, err := core.DescribeDBClusterSnapshots(ctx, &rds.DescribeDBClusterSnapshotsInput{
+		DBClusterSnapshotIdentifier: p.describeParam.DBClusterSnapshotIdentifier,
+	})
+	if err != nil {
+		return nil, err
+	}
+	desc := &DescClusterSnapshot{}
+	if len(output.DBClusterSnapshots) > 0 {
+		desc = convertDBClusterSnapshot(output.DBClusterSnapshots[0])
+	}
+	return desc, nil
+}
+
+func (p *clusterSnapshotParams) list(ctx context.Context, core API) ([]*DescClusterSnapshot, error) {
+	paginator := rds.NewDescribeDBClusterSnapshotsPaginator(core, &rds.DescribeDBClusterSnapshotsInput{
+		DBClusterIdentifier: p.describeParam.DBClusterIdentifier,
+	})
+
+	descs := []*DescClusterSnapshot{}
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, snapshot := range output.DBClusterSnapshots {
+			descs = append(descs, convertDBClusterSnapshot(snapshot))
+		}
+	}
+	return descs, nil
+}
+
+func (p *clusterSnapshotParams) copy(ctx context.Context, core API) error {
+	_, err := core.CopyDBClusterSnapshot(ctx, p.copyParam)
+	return err
+}
+
+func (p *clusterSnapshotParams) delete(ctx context.Context, core API) error {
+	_, err := core.DeleteDBClusterSnapshot(ctx, p.deleteParam)
+	return err
+}
+
+func (p *clusterSnapshotParams) restore(ctx context.Context, core API) error {
+	_, err := core.RestoreDBClusterFromSnapshot(ctx, p.restoreParam)
+	return err
+}
+
+func (p *clusterSnapshotParams) waitUntilAvailable(ctx context.Context, core API, opts ...WaitOption) error {
+	return wait(ctx, func(ctx context.Context) (string, bool, error) {
+		desc, err := p.describe(ctx, core)
+		if err != nil {
+			return "", false, err
+		}
+		return desc.Status, desc.Status == StatusAvailable, nil
+	}, opts...)
+}
+
+// SetDBClusterSnapshotIdentifier names the snapshot to work on. For
+// CopySnapshot it is the name of the new snapshot.
+func (s *rdsCluster) SetDBClusterSnapshotIdentifier(id string) Cluster {
+	s.snapshot.setDBClusterSnapshotIdentifier(id)
+	return s
+}
+
+// SetSourceDBClusterSnapshotIdentifier sets the snapshot to copy. It must be
+// an ARN when copying from another region.
+func (s *rdsCluster) SetSourceDBClusterSnapshotIdentifier(id string) Cluster {
+	s.snapshot.copyParam.SourceDBClusterSnapshotIdentifier = aws.String(id)
+	return s
+}
+
+// SetSourceRegion makes CopySnapshot a cross-region copy.
+func (s *rdsCluster) SetSourceRegion(region string) Cluster {
+	s.snapshot.copyParam.SourceRegion = aws.String(region)
+	return s
+}
+
+// SetKmsKeyId sets the key encrypting the cluster on Create and
+// RestoreFromSnapshot, and the snapshot on CopySnapshot.
+func (s *rdsCluster) SetKmsKeyId(id string) Cluster {
+	s.createClusterParam.KmsKeyId = aws.String(id)
+	s.snapshot.copyParam.KmsKeyId = aws.String(id)
+	s.snapshot.restoreParam.KmsKeyId = aws.String(id)
+	return s
+}
+
+func (s *rdsCluster) CreateSnapshot(ctx context.Context) error {
+	return s.snapshot.create(ctx, s.core)
+}
+
+func (s *rdsCluster) DescribeSnapshot(ctx context.Context) (*DescClusterSnapshot, error) {
+	return s.snapshot.describe(ctx, s.core)
+}
+
+// ListSnapshots returns the snapshots of the cluster set by
+// SetDBClusterIdentifier, or all of them, following pagination.
+func (s *rdsCluster) ListSnapshots(ctx context.Context) ([]*DescClusterSnapshot, error) {
+	return s.snapshot.list(ctx, s.core)
+}
+
+func (s *rdsCluster) CopySnapshot(ctx context.Context) error {
+	return s.snapshot.copy(ctx, s.core)
+}
+
+func (s *rdsCluster) DeleteSnapshot(ctx context.Context) error {
+	return s.snapshot.delete(ctx, s.core)
+}
+
+// RestoreFromSnapshot creates the cluster set by SetDBClusterIdentifier from
+// the snapshot. Multi-AZ DB clusters are restored with their instances.
+func (s *rdsCluster) RestoreFromSnapshot(ctx context.Context) error {
+	return s.snapshot.restore(ctx, s.core)
+}
+
+func (s *rdsCluster) WaitUntilSnapshotAvailable(ctx context.Context, opts ...WaitOption) error {
+	return s.snapshot.waitUntilAvailable(ctx, s.core, opts...)
+}
+
+// SetDBClusterSnapshotIdentifier names the snapshot to work on. For
+// CopySnapshot it is the name of the new snapshot.
+func (s *rdsAurora) SetDBClusterSnapshotIdentifier(id string) Aurora {
+	s.snapshot.setDBClusterSnapshotIdentifier(id)
+	return s
+}
+
+// SetSourceDBClusterSnapshotIdentifier sets the snapshot to copy. It must be
+// an ARN when copying from another region.
+func (s *rdsAurora) SetSourceDBClusterSnapshotIdentifier(id string) Aurora {
+	s.snapshot.copyParam.SourceDBClusterSnapshotIdentifier = aws.String(id)
+	return s
+}
+
+// SetSourceRegion makes CopySnapshot a cross-region copy.
+func (s *rdsAurora) SetSourceRegion(region string) Aurora {
+	s.snapshot.copyParam.SourceRegion = aws.String(region)
+	return s
+}
+
+// SetKmsKeyId sets the key encrypting the cluster on Create and
+// RestoreFromSnapshot, and the snapshot on CopySnapshot.
+func (s *rdsAurora) SetKmsKeyId(id string) Aurora {
+	s.createClusterParam.KmsKeyId = aws.String(id)
+	s.snapshot.copyParam.KmsKeyId = aws.String(id)
+	s.snapshot.restoreParam.KmsKeyId = aws.String(id)
+	return s
+}
+
+func (s *rdsAurora) CreateSnapshot(ctx context.Context) error {
+	return s.snapshot.create(ctx, s.core)
+}
+
+func (s *rdsAurora) DescribeSnapshot(ctx context.Context) (*DescClusterSnapshot, error) {
+	return s.snapshot.describe(ctx, s.core)
+}
+
+// ListSnapshots returns the snapshots of the cluster set by
+// SetDBClusterIdentifier, or all of them, following pagination.
+func (s *rdsAurora) ListSnapshots(ctx context.Context) ([]*DescClusterSnapshot, error) {
+	return s.snapshot.list(ctx, s.core)
+}
+
+func (s *rdsAurora) CopySnapshot(ctx context.Context) error {
+	return s.snapshot.copy(ctx, s.core)
+}
+
+func (s *rdsAurora) DeleteSnapshot(ctx context.Context) error {
+	return s.snapshot.delete(ctx, s.core)
+}
+
+// RestoreFromSnapshot creates the cluster set by SetDBClusterIdentifier from
+// the snapshot. Aurora restores no instance, so like CreateWithPrimary a
+// primary is created when SetDBInstanceIdentifier was called.
+func (s *rdsAurora) RestoreFromSnapshot(ctx context.Context) error {
+	if err := s.snapshot.restore(ctx, s.core); err != nil {
+		return err
+	}
+
+	if s.createInstanceParam.DBInstanceIdentifier == nil {
+		return nil
+	}
+	_, err := s.core.CreateDBInstance(ctx, s.primaryInstanceParam())
+	return err
+}
+
+func (s *rdsAurora) WaitUntilSnapshotAvailable(ctx context.Context, opts ...WaitOption) error {
+	return s.snapshot.waitUntilAvailable(ctx, s.core, opts...)
+}
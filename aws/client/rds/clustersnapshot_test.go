@@ -0,0 +1,125 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/database-mesh/golang-sdk/aws/client/rds/fake"
+)
+
+func Test_ClusterSnapshotMultiAZ(t *testing.T) {
+	f := fake.New().SetAutoAdvance(true)
+	svc := NewServiceWithAPI(f)
+
+	err := svc.Cluster().
+		SetEngine("postgres").
+		SetDBClusterIdentifier(TestDBIdentifier).
+		SetDBClusterInstanceClass("db.m5d.large").
+		SetAllocatedStorage(100).
+		SetIOPS(1000).
+		SetStorageType("io1").
+		SetMasterUsername("postgres").
+		SetMasterUserPassword(TestDBPass).
+		Create(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := svc.Cluster().SetDBClusterIdentifier(TestDBIdentifier).WaitUntilAvailable(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+
+	snapshot := svc.Cluster().SetDBClusterIdentifier(TestDBIdentifier).SetDBClusterSnapshotIdentifier("foo-snap")
+	if err := snapshot.CreateSnapshot(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := snapshot.WaitUntilSnapshotAvailable(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := svc.Cluster().SetDBClusterIdentifier(TestDBIdentifier).SetDBClusterSnapshotIdentifier("foo-snap-copy").
+		SetSourceDBClusterSnapshotIdentifier("foo-snap").CopySnapshot(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	descs, err := svc.Cluster().SetDBClusterIdentifier(TestDBIdentifier).ListSnapshots(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(descs) != 2 || descs[0].DBClusterSnapshotIdentifier != "foo-snap" || descs[0].Engine != "postgres" {
+		t.Fatalf("unexpected snapshots %#v\n", descs)
+	}
+
+	restore := svc.Cluster().SetEngine("postgres").SetDBClusterIdentifier("bar").SetDBClusterSnapshotIdentifier("foo-snap")
+	if err := restore.RestoreFromSnapshot(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	desc, err := svc.Cluster().SetDBClusterIdentifier("bar").Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(desc.DBClusterMembers) != 3 {
+		t.Fatalf("unexpected cluster %#v\n", desc)
+	}
+
+	if err := snapshot.DeleteSnapshot(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if _, err := snapshot.DescribeSnapshot(context.TODO()); err == nil {
+		t.Fatalf("expected snapshot not found\n")
+	}
+}
+
+func Test_ClusterSnapshotAurora(t *testing.T) {
+	f := fake.New()
+	svc := NewServiceWithAPI(f)
+
+	err := svc.Aurora().
+		SetEngine("aurora-mysql").
+		SetDBClusterIdentifier(TestDBIdentifier).
+		SetDBInstanceIdentifier("foo-instance-1").
+		SetDBInstanceClass("db.r5.large").
+		SetMasterUsername("admin").
+		SetMasterUserPassword(TestDBPass).
+		CreateWithPrimary(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	f.Settle()
+
+	if err := svc.Aurora().SetDBClusterIdentifier(TestDBIdentifier).SetDBClusterSnapshotIdentifier("foo-snap").CreateSnapshot(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	f.Settle()
+
+	err = svc.Aurora().
+		SetEngine("aurora-mysql").
+		SetDBClusterIdentifier("bar").
+		SetDBClusterSnapshotIdentifier("foo-snap").
+		SetDBInstanceIdentifier("bar-instance-1").
+		SetDBInstanceClass("db.r5.large").
+		RestoreFromSnapshot(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	f.Settle()
+	desc, err := svc.Aurora().SetDBClusterIdentifier("bar").Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if desc.Writer() != "bar-instance-1" {
+		t.Fatalf("unexpected cluster %#v\n", desc)
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+const (
+	EndpointTypeReader = "READER"
+	EndpointTypeAny    = "ANY"
+)
+
+// FailoverPolicy decides which reader FailoverRandomOneReadonlyEndpoint
+// promotes.
+type FailoverPolicy string
+
+const (
+	FailoverPolicyRandom FailoverPolicy = "random"
+	// FailoverPolicyPromotionTier picks the reader with the lowest promotion
+	// tier like RDS does, the identifier breaks ties.
+	FailoverPolicyPromotionTier FailoverPolicy = "promotion-tier"
+)
+
+var ErrNoFailoverTarget = errors.New("no available reader to fail over to")
+
+func (s *rdsAurora) SetDBClusterEndpointIdentifier(id string) Aurora {
+	s.createEndpointParam.DBClusterEndpointIdentifier = aws.String(id)
+	s.modifyEndpointParam.DBClusterEndpointIdentifier = aws.String(id)
+	s.deleteEndpointParam.DBClusterEndpointIdentifier = aws.String(id)
+	s.describeEndpointParam.DBClusterEndpointIdentifier = aws.String(id)
+	return s
+}
+
+// SetEndpointType sets the type of the custom endpoint, EndpointTypeReader by
+// default.
+func (s *rdsAurora) SetEndpointType(t string) Aurora {
+	s.createEndpointParam.EndpointType = aws.String(t)
+	s.modifyEndpointParam.EndpointType = aws.String(t)
+	return s
+}
+
+// NOTE: StaticMembers and ExcludedMembers are mutually exclusive. Without
+// static members every instance not excluded is part of the endpoint,
+// including instances added later.
+func (s *rdsAurora) SetStaticMembers(ids []string) Aurora {
+	s.createEndpointParam.StaticMembers = ids
+	s.modifyEndpointParam.StaticMembers = ids
+	return s
+}
+
+func (s *rdsAurora) SetExcludedMembers(ids []string) Aurora {
+	s.createEndpointParam.ExcludedMembers = ids
+	s.modifyEndpointParam.ExcludedMembers = ids
+	return s
+}
+
+func (s *rdsAurora) SetFailoverPolicy(policy FailoverPolicy) Aurora {
+	s.failoverPolicy = policy
+	return s
+}
+
+// NewReadonlyEndpoint creates the custom endpoint set by
+// SetDBClusterEndpointIdentifier.
+func (s *rdsAurora) NewReadonlyEndpoint(ctx context.Context) error {
+	param := *s.createEndpointParam
+	param.DBClusterIdentifier = s.describeClusterParam.DBClusterIdentifier
+	if param.EndpointType == nil {
+		param.EndpointType = aws.String(EndpointTypeReader)
+	}
+	_, err := s.core.CreateDBClusterEndpoint(ctx, &param)
+	return err
+}
+
+func (s *rdsAurora) ModifyCustomEndpoint(ctx context.Context) error {
+	_, err := s.core.ModifyDBClusterEndpoint(ctx, s.modifyEndpointParam)
+	return err
+}
+
+func (s *rdsAurora) DeleteCustomEndpoint(ctx context.Context) error {
+	_, err := s.core.DeleteDBClusterEndpoint(ctx, s.deleteEndpointParam)
+	return err
+}
+
+type DescClusterEndpoint struct {
+	DBClusterEndpointIdentifier string
+	DBClusterEndpointArn        string
+	DBClusterIdentifier         string
+	Endpoint                    string
+	EndpointType                string
+	CustomEndpointType          string
+	Status                      string
+	StaticMembers               []string
+	ExcludedMembers             []string
+}
+
+func convertDBClusterEndpoint(endpoint types.DBClusterEndpoint) *DescClusterEndpoint {
+	return &DescClusterEndpoint{
+		DBClusterEndpointIdentifier: aws.ToString(endpoint.DBClusterEndpointIdentifier),
+		DBClusterEndpointArn:        aws.ToString(endpoint.DBClusterEndpointArn),
+		DBClusterIdentifier:         aws.ToString(endpoint.DBClusterIdentifier),
+		Endpoint:                    aws.ToString(endpoint.Endpoint),
+		EndpointType:                aws.ToString(endpoint.EndpointType),
+		CustomEndpointType:          aws.ToString(endpoint.CustomEndpointType),
+		Status:                      aws.ToString(endpoint.Status),
+		StaticMembers:               endpoint.StaticMembers,
+		ExcludedMembers:             endpoint.ExcludedMembers,
+	}
+}
+
+// ListCustomEndpoints returns the custom endpoints of the cluster, or only
+// the one set by SetDBClusterEndpointIdentifier.
+func (s *rdsAurora) ListCustomEndpoints(ctx context.Context) ([]*DescClusterEndpoint, error) {
+	param := *s.describeEndpointParam
+	param.DBClusterIdentifier = s.describeClusterParam.DBClusterIdentifier
+	param.Filters = []types.Filter{
+		{Name: aws.String("db-cluster-endpoint-type"), Values: []string{"custom"}},
+	}
+
+	paginator := rds.NewDescribeDBClusterEndpointsPaginator(s.core, &param)
+
+	descs := []*DescClusterEndpoint{}
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range output.DBClusterEndpoints {
+			descs = append(descs, convertDBClusterEndpoint(e))
+		}
+	}
+	return descs, nil
+}
+
+// WaitUntilCustomEndpointAvailable waits for the endpoint to be available
+// after NewReadonlyEndpoint or ModifyCustomEndpoint.
+func (s *rdsAurora) WaitUntilCustomEndpointAvailable(ctx context.Context, opts ...WaitOption) error {
+	return wait(ctx, func(ctx context.Context) (string, bool, error) {
+		output, err := s.core.DescribeDBClusterEndpoints(ctx, s.describeEndpointParam)
+		if err != nil {
+			return "", false, err
+		}
+		if len(output.DBClusterEndpoints) == 0 {
+			return "", false, nil
+		}
+		status := aws.ToString(output.DBClusterEndpoints[0].Status)
+		return status, status == StatusAvailable, nil
+	}, opts...)
+}
+
+// failoverRand picks the readers of FailoverPolicyRandom. It is shared by
+// every builder, and rand.Rand is not safe for concurrent use.
+var (
+	failoverRandMu sync.Mutex
+	failoverRand   = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// FailoverRandomOneReadonlyEndpoint fails the cluster over to an available
+// reader picked by the FailoverPolicy, FailoverPolicyRandom by default. The
+// readers are limited to the members of the custom endpoint when
+// SetDBClusterEndpointIdentifier was called. It returns the identifier of the
+// new writer, WaitUntilFailoverComplete waits for it.
+func (s *rdsAurora) FailoverRandomOneReadonlyEndpoint(ctx context.Context) (string, error) {
+	readers, err := s.failoverCandidates(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(readers) == 0 {
+		return "", ErrNoFailoverTarget
+	}
+
+	var target types.DBInstance
+	switch s.failoverPolicy {
+	case FailoverPolicyPromotionTier:
+		sort.Slice(readers, func(i, j int) bool {
+			ti, tj := aws.ToInt32(readers[i].PromotionTier), aws.ToInt32(readers[j].PromotionTier)
+			if ti != tj {
+				return ti < tj
+			}
+			return aws.ToString(readers[i].DBInstanceIdentifier) < aws.ToString(readers[j].DBInstanceIdentifier)
+		})
+		target = readers[0]
+	default:
+		failoverRandMu.Lock()
+		target = readers[failoverRand.Intn(len(readers))]
+		failoverRandMu.Unlock()
+	}
+
+	// The target is not kept in the builder, so that a later FailoverPrimary
+	// lets RDS pick the writer again.
+	params := *s.failoverClusterParam
+	params.TargetDBInstanceIdentifier = target.DBInstanceIdentifier
+	if _, err := s.core.FailoverDBCluster(ctx, &params); err != nil {
+		return "", err
+	}
+	// The target is a reader, so the failover happened once it is the writer.
+	s.failoverTarget = target.DBInstanceIdentifier
+	s.failoverFrom = ""
+	return aws.ToString(target.DBInstanceIdentifier), nil
+}
+
+// failoverCandidates returns the available readers of the cluster.
+func (s *rdsAurora) failoverCandidates(ctx context.Context) ([]types.DBInstance, error) {
+	desc, err := describeCluster(ctx, s.core, s.describeClusterParam)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoint *types.DBClusterEndpoint
+	if s.describeEndpointParam.DBClusterEndpointIdentifier != nil {
+		output, err := s.core.DescribeDBClusterEndpoints(ctx, s.describeEndpointParam)
+		if err != nil {
+			return nil, err
+		}
+		if len(output.DBClusterEndpoints) > 0 {
+			endpoint = &output.DBClusterEndpoints[0]
+		}
+	}
+
+	instances, err := describeClusterInstances(ctx, s.core, desc.DBClusterIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	readers := []types.DBInstance{}
+	for _, i := range instances {
+		id := aws.ToString(i.DBInstanceIdentifier)
+		if id == desc.Writer() || aws.ToString(i.DBInstanceStatus) != StatusAvailable {
+			continue
+		}
+		if endpoint != nil && !endpointMember(endpoint, id) {
+			continue
+		}
+		readers = append(readers, i)
+	}
+	return readers, nil
+}
+
+func endpointMember(endpoint *types.DBClusterEndpoint, id string) bool {
+	if len(endpoint.StaticMembers) > 0 {
+		return contains(endpoint.StaticMembers, id)
+	}
+	return !contains(endpoint.ExcludedMembers, id)
+}
@@ -0,0 +1,141 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/database-mesh/golang-sdk/aws/client/rds/fake"
+)
+
+func newAuroraWithReaders(t *testing.T, f *fake.RDS, tiers ...int32) {
+	err := NewServiceWithAPI(f).Aurora().
+		SetEngine("aurora-mysql").
+		SetDBClusterIdentifier(TestDBIdentifier).
+		SetDBInstanceIdentifier("foo-instance-0").
+		SetDBInstanceClass("db.r5.large").
+		SetMasterUsername("admin").
+		SetMasterUserPassword(TestDBPass).
+		CreateWithPrimary(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	for n, tier := range tiers {
+		_, err := f.CreateDBInstance(context.TODO(), &rds.CreateDBInstanceInput{
+			DBInstanceIdentifier: aws.String(fmt.Sprintf("foo-instance-%d", n+1)),
+			DBInstanceClass:      aws.String("db.r5.large"),
+			Engine:               aws.String("aurora-mysql"),
+			DBClusterIdentifier:  aws.String(TestDBIdentifier),
+			PromotionTier:        aws.Int32(tier),
+		})
+		if err != nil {
+			t.Fatalf("%+v\n", err)
+		}
+	}
+	f.Settle()
+}
+
+func Test_CustomEndpoint(t *testing.T) {
+	f := fake.New().SetAutoAdvance(true)
+	newAuroraWithReaders(t, f, 1, 1)
+	aurora := NewServiceWithAPI(f).Aurora().
+		SetDBClusterIdentifier(TestDBIdentifier).
+		SetDBClusterEndpointIdentifier("foo-analytics").
+		SetStaticMembers([]string{"foo-instance-1"})
+
+	if err := aurora.NewReadonlyEndpoint(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := aurora.WaitUntilCustomEndpointAvailable(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := aurora.SetStaticMembers(nil).SetExcludedMembers([]string{"foo-instance-1"}).ModifyCustomEndpoint(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	descs, err := aurora.ListCustomEndpoints(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(descs) != 1 || descs[0].CustomEndpointType != EndpointTypeReader || len(descs[0].ExcludedMembers) != 1 {
+		t.Fatalf("unexpected endpoints %#v\n", descs)
+	}
+
+	// The endpoint only contains foo-instance-2 and the writer.
+	writer, err := aurora.FailoverRandomOneReadonlyEndpoint(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if writer != "foo-instance-2" {
+		t.Fatalf("unexpected writer %s\n", writer)
+	}
+	if err := aurora.WaitUntilFailoverComplete(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+
+	if err := aurora.DeleteCustomEndpoint(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	desc, err := aurora.Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(desc.CustomEndpoints) != 0 {
+		t.Fatalf("unexpected cluster %#v\n", desc)
+	}
+}
+
+func Test_FailoverPolicy(t *testing.T) {
+	f := fake.New().SetAutoAdvance(true)
+	newAuroraWithReaders(t, f, 2, 0, 1)
+	aurora := NewServiceWithAPI(f).Aurora().SetDBClusterIdentifier(TestDBIdentifier)
+
+	writer, err := aurora.SetFailoverPolicy(FailoverPolicyPromotionTier).FailoverRandomOneReadonlyEndpoint(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if writer != "foo-instance-2" {
+		t.Fatalf("unexpected writer %s\n", writer)
+	}
+	if err := aurora.WaitUntilFailoverComplete(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+
+	writer, err = aurora.SetFailoverPolicy(FailoverPolicyRandom).FailoverRandomOneReadonlyEndpoint(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if writer == "foo-instance-2" {
+		t.Fatalf("unexpected writer %s\n", writer)
+	}
+	if err := aurora.WaitUntilFailoverComplete(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+
+	// The picked reader does not stick to the builder.
+	if target := aurora.(*rdsAurora).failoverClusterParam.TargetDBInstanceIdentifier; target != nil {
+		t.Fatalf("unexpected failover target %s\n", *target)
+	}
+	if err := aurora.FailoverPrimary(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := aurora.WaitUntilFailoverComplete(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+}
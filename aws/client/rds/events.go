@@ -0,0 +1,110 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+// Event is an event RDS recorded for a source, such as the failure of an
+// operation which completes asynchronously.
+type Event struct {
+	SourceIdentifier string
+	SourceType       string
+	SourceArn        string
+	Message          string
+	EventCategories  []string
+	Date             time.Time
+}
+
+// EventFilter selects the events of a source. RDS keeps events for 14 days.
+type EventFilter struct {
+	// StartTime and EndTime bound the events. EndTime defaults to now.
+	StartTime time.Time
+	EndTime   time.Time
+	// Duration selects the events before EndTime when StartTime is not set.
+	// It defaults to one hour and is rounded down to minutes.
+	Duration time.Duration
+	// EventCategories such as "failure" or "backup" select the events of
+	// any of them.
+	EventCategories []string
+}
+
+// Events returns the events of the instance, oldest first. A nil filter
+// returns the events of the last hour.
+func (s *rdsInstance) Events(ctx context.Context, filter *EventFilter) ([]*Event, error) {
+	return describeEvents(ctx, s.core, types.SourceTypeDbInstance, s.describeInstanceParam.DBInstanceIdentifier, filter)
+}
+
+// Events returns the events of the cluster, oldest first. Events of its
+// instances are returned by Instance.Events.
+func (s *rdsCluster) Events(ctx context.Context, filter *EventFilter) ([]*Event, error) {
+	return describeEvents(ctx, s.core, types.SourceTypeDbCluster, s.describeClusterParam.DBClusterIdentifier, filter)
+}
+
+// Events returns the events of the cluster, oldest first. Events of its
+// instances are returned by Instance.Events.
+func (s *rdsAurora) Events(ctx context.Context, filter *EventFilter) ([]*Event, error) {
+	return describeEvents(ctx, s.core, types.SourceTypeDbCluster, s.describeClusterParam.DBClusterIdentifier, filter)
+}
+
+// Events returns the events of the snapshot, oldest first.
+func (s *rdsSnapshot) Events(ctx context.Context, filter *EventFilter) ([]*Event, error) {
+	return describeEvents(ctx, s.core, types.SourceTypeDbSnapshot, s.describeParam.DBSnapshotIdentifier, filter)
+}
+
+func describeEvents(ctx context.Context, core API, sourceType types.SourceType, id *string, filter *EventFilter) ([]*Event, error) {
+	params := &rds.DescribeEventsInput{
+		SourceType:       sourceType,
+		SourceIdentifier: id,
+	}
+	if filter != nil {
+		if !filter.StartTime.IsZero() {
+			params.StartTime = aws.Time(filter.StartTime)
+		}
+		if !filter.EndTime.IsZero() {
+			params.EndTime = aws.Time(filter.EndTime)
+		}
+		if filter.Duration > 0 {
+			params.Duration = aws.Int32(int32(filter.Duration / time.Minute))
+		}
+		params.EventCategories = filter.EventCategories
+	}
+	paginator := rds.NewDescribeEventsPaginator(core, params)
+
+	events := []*Event{}
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range output.Events {
+			events = append(events, &Event{
+				SourceIdentifier: aws.ToString(e.SourceIdentifier),
+				SourceType:       string(e.SourceType),
+				SourceArn:        aws.ToString(e.SourceArn),
+				Message:          aws.ToString(e.Message),
+				EventCategories:  e.EventCategories,
+				Date:             aws.ToTime(e.Date),
+			})
+		}
+	}
+	return events, nil
+}
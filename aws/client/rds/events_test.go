@@ -0,0 +1,90 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/database-mesh/golang-sdk/aws/client/rds/fake"
+)
+
+func Test_Events(t *testing.T) {
+	f := fake.New()
+	svc := NewServiceWithAPI(f)
+
+	instance := svc.Instance().
+		SetEngine("mysql").
+		SetEngineVersion("8.0.28").
+		SetDBInstanceIdentifier(TestDBIdentifier).
+		SetDBInstanceClass("db.m5.large").
+		SetAllocatedStorage(40)
+	if err := instance.Create(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	f.Settle()
+	if err := instance.Reboot(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	f.Settle()
+	f.RecordEvent(types.SourceTypeDbInstance, TestDBIdentifier, "Storage is full", "failure")
+
+	events, err := instance.Events(context.TODO(), nil)
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(events) != 3 || events[0].Message != "DB instance created" || events[1].Message != "DB instance restarted" {
+		t.Fatalf("unexpected events %#v\n", events)
+	}
+	events, err = instance.Events(context.TODO(), &EventFilter{EventCategories: []string{"failure"}})
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(events) != 1 || events[0].Message != "Storage is full" || events[0].SourceType != string(types.SourceTypeDbInstance) {
+		t.Fatalf("unexpected events %#v\n", events)
+	}
+	events, err = instance.Events(context.TODO(), &EventFilter{EndTime: time.Now().Add(-time.Hour)})
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("unexpected events %#v\n", events)
+	}
+
+	snapshot := svc.Snapshot().SetDBSnapshotIdentifier("foo-snapshot").SetDBInstanceIdentifier(TestDBIdentifier)
+	if err := snapshot.Create(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	f.Settle()
+	events, err = snapshot.Events(context.TODO(), &EventFilter{StartTime: time.Now().Add(-time.Minute)})
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(events) != 1 || events[0].SourceIdentifier != "foo-snapshot" {
+		t.Fatalf("unexpected events %#v\n", events)
+	}
+
+	// Cluster events do not include the events of its instances.
+	newAuroraWithReaders(t, f)
+	events, err = svc.Aurora().SetDBClusterIdentifier(TestDBIdentifier).Events(context.TODO(), &EventFilter{Duration: 10 * time.Minute})
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(events) != 1 || events[0].Message != "DB cluster created" {
+		t.Fatalf("unexpected events %#v\n", events)
+	}
+}
@@ -0,0 +1,435 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+// multiAZClusterSize is the number of instances of a Multi-AZ DB cluster:
+// one writer and two readers.
+const multiAZClusterSize = 3
+
+func clusterNotFound(id string) error {
+	return &types.DBClusterNotFoundFault{Message: aws.String(fmt.Sprintf("DBCluster %s not found.", id))}
+}
+
+func invalidClusterState(id, status string) error {
+	return &types.InvalidDBClusterStateFault{Message: aws.String(fmt.Sprintf("DBCluster %s is in %s state.", id, status))}
+}
+
+// copy returns the cluster without sharing the members with the caller.
+func (c *dbCluster) copy() types.DBCluster {
+	out := c.DBCluster
+	out.DBClusterMembers = append([]types.DBClusterMember(nil), c.DBClusterMembers...)
+	return out
+}
+
+func (f *RDS) addMember(c *dbCluster, i *dbInstance) {
+	c.DBClusterMembers = append(c.DBClusterMembers, types.DBClusterMember{
+		DBInstanceIdentifier:          i.DBInstanceIdentifier,
+		DBClusterParameterGroupStatus: aws.String("in-sync"),
+		IsClusterWriter:               len(c.DBClusterMembers) == 0,
+		PromotionTier:                 i.PromotionTier,
+	})
+}
+
+func (f *RDS) removeMember(i *dbInstance) {
+	c, ok := f.clusters[aws.ToString(i.DBClusterIdentifier)]
+	if !ok {
+		return
+	}
+	members := []types.DBClusterMember{}
+	writer := false
+	for _, m := range c.DBClusterMembers {
+		if aws.ToString(m.DBInstanceIdentifier) == aws.ToString(i.DBInstanceIdentifier) {
+			writer = m.IsClusterWriter
+			continue
+		}
+		members = append(members, m)
+	}
+	// Promote a reader like Aurora does when the writer goes away.
+	if writer && len(members) > 0 {
+		sortReaders(members)
+		members[0].IsClusterWriter = true
+	}
+	c.DBClusterMembers = members
+}
+
+// sortReaders orders members by promotion tier, then identifier, which is
+// the order in which they are promoted.
+func sortReaders(members []types.DBClusterMember) {
+	sort.SliceStable(members, func(i, j int) bool {
+		ti, tj := aws.ToInt32(members[i].PromotionTier), aws.ToInt32(members[j].PromotionTier)
+		if ti != tj {
+			return ti < tj
+		}
+		return aws.ToString(members[i].DBInstanceIdentifier) < aws.ToString(members[j].DBInstanceIdentifier)
+	})
+}
+
+func (f *RDS) newCluster(id string, engine, version *string) *dbCluster {
+	c := &dbCluster{DBCluster: types.DBCluster{
+		DBClusterIdentifier: aws.String(id),
+		DBClusterArn:        aws.String(f.arn("cluster", id)),
+		DbClusterResourceId: aws.String(f.nextID("cluster")),
+		Engine:              engine,
+		EngineVersion:       version,
+		EngineMode:          aws.String("provisioned"),
+		Status:              aws.String(StatusCreating),
+		ClusterCreateTime:   now(),
+		Endpoint:            aws.String(f.host(id, "cluster-")),
+		ReaderEndpoint:      aws.String(f.host(id, "cluster-ro-")),
+		Port:                aws.Int32(defaultPort(aws.ToString(engine))),
+		DeletionProtection:  aws.Bool(false),
+		MultiAZ:             aws.Bool(false),
+	}, transitions: []string{StatusAvailable}}
+	if isAurora(aws.ToString(engine)) {
+		c.DBClusterParameterGroup = aws.String("default." + aws.ToString(engine))
+	}
+	return c
+}
+
+// addMultiAZInstances creates the instances AWS creates along with a
+// Multi-AZ DB cluster.
+func (f *RDS) addMultiAZInstances(c *dbCluster) {
+	id := aws.ToString(c.DBClusterIdentifier)
+	for n := 1; n <= multiAZClusterSize; n++ {
+		iid := fmt.Sprintf("%s-instance-%d", id, n)
+		i := &dbInstance{DBInstance: types.DBInstance{
+			DBInstanceIdentifier: aws.String(iid),
+			DBInstanceArn:        aws.String(f.arn("db", iid)),
+			DbiResourceId:        aws.String(f.nextID("db")),
+			DBInstanceClass:      c.DBClusterInstanceClass,
+			Engine:               c.Engine,
+			EngineVersion:        c.EngineVersion,
+			MasterUsername:       c.MasterUsername,
+			DBName:               c.DatabaseName,
+			AllocatedStorage:     aws.ToInt32(c.AllocatedStorage),
+			Iops:                 c.Iops,
+			StorageType:          c.StorageType,
+			DBClusterIdentifier:  c.DBClusterIdentifier,
+			DBInstanceStatus:     aws.String(StatusCreating),
+			InstanceCreateTime:   now(),
+			DbInstancePort:       aws.ToInt32(c.Port),
+			Endpoint:             &types.Endpoint{Address: aws.String(f.host(iid, "")), Port: aws.ToInt32(c.Port)},
+		}, transitions: []string{StatusAvailable}}
+		f.instances[iid] = i
+		f.addMember(c, i)
+	}
+	c.MultiAZ = aws.Bool(true)
+}
+
+func (f *RDS) CreateDBCluster(_ context.Context, params *rds.CreateDBClusterInput, _ ...func(*rds.Options)) (*rds.CreateDBClusterOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := aws.ToString(params.DBClusterIdentifier)
+	if id == "" {
+		return nil, missingParameter("DBClusterIdentifier")
+	}
+	if _, ok := f.clusters[id]; ok {
+		return nil, &types.DBClusterAlreadyExistsFault{Message: aws.String(fmt.Sprintf("DBCluster %s already exists.", id))}
+	}
+	engine := aws.ToString(params.Engine)
+	if engine == "" {
+		return nil, missingParameter("Engine")
+	}
+	if !isAurora(engine) && aws.ToString(params.DBClusterInstanceClass) == "" {
+		return nil, invalidParameterCombination("DBClusterInstanceClass is required for Multi-AZ DB clusters.")
+	}
+	if name := aws.ToString(params.DBSubnetGroupName); name != "" {
+		if _, ok := f.subnetGroups[name]; !ok {
+			return nil, subnetGroupNotFound(name)
+		}
+	}
+
+	c := f.newCluster(id, params.Engine, params.EngineVersion)
+	if params.EngineMode != nil {
+		c.EngineMode = params.EngineMode
+	}
+	c.MasterUsername = params.MasterUsername
+	c.DatabaseName = params.DatabaseName
+	c.AvailabilityZones = params.AvailabilityZones
+	c.DBClusterInstanceClass = params.DBClusterInstanceClass
+	c.AllocatedStorage = params.AllocatedStorage
+	c.StorageType = params.StorageType
+	c.Iops = params.Iops
+	c.DBSubnetGroup = params.DBSubnetGroupName
+	c.GlobalWriteForwardingRequested = params.EnableGlobalWriteForwarding
+	c.TagList = params.Tags
+	if params.Port != nil {
+		c.Port = params.Port
+	}
+	if params.DeletionProtection != nil {
+		c.DeletionProtection = params.DeletionProtection
+	}
+	for _, sg := range params.VpcSecurityGroupIds {
+		c.VpcSecurityGroups = append(c.VpcSecurityGroups, types.VpcSecurityGroupMembership{
+			VpcSecurityGroupId: aws.String(sg),
+			Status:             aws.String("active"),
+		})
+	}
+	if group := aws.ToString(params.DBClusterParameterGroupName); group != "" {
+		if err := f.attachParameterGroup(group, engine, true); err != nil {
+			return nil, err
+		}
+		c.DBClusterParameterGroup = aws.String(group)
+	}
+	if err := setScaling(c, params.ScalingConfiguration, params.ServerlessV2ScalingConfiguration); err != nil {
+		return nil, err
+	}
+	if gid := aws.ToString(params.GlobalClusterIdentifier); gid != "" {
+		if err := f.joinGlobalCluster(gid, c); err != nil {
+			return nil, err
+		}
+	}
+
+	f.clusters[id] = c
+	if !isAurora(engine) {
+		f.addMultiAZInstances(c)
+	}
+	out := c.copy()
+	return &rds.CreateDBClusterOutput{DBCluster: &out}, nil
+}
+
+func (f *RDS) DeleteDBCluster(_ context.Context, params *rds.DeleteDBClusterInput, _ ...func(*rds.Options)) (*rds.DeleteDBClusterOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := aws.ToString(params.DBClusterIdentifier)
+	c, ok := f.clusters[id]
+	if !ok {
+		return nil, clusterNotFound(id)
+	}
+	if status := aws.ToString(c.Status); status == StatusDeleting {
+		return nil, invalidClusterState(id, status)
+	}
+	if err := f.checkGlobalMember(c); err != nil {
+		return nil, err
+	}
+	if aws.ToBool(c.DeletionProtection) {
+		return nil, invalidParameterCombination("Cannot delete protected Cluster, please disable deletion protection and try again.")
+	}
+	if !params.SkipFinalSnapshot && aws.ToString(params.FinalDBSnapshotIdentifier) == "" {
+		return nil, invalidParameterCombination("FinalDBSnapshotIdentifier is required unless SkipFinalSnapshot is specified.")
+	}
+
+	members := []*dbInstance{}
+	for _, m := range c.DBClusterMembers {
+		i := f.instances[aws.ToString(m.DBInstanceIdentifier)]
+		if i != nil && aws.ToString(i.DBInstanceStatus) != StatusDeleting {
+			members = append(members, i)
+		}
+	}
+	if isAurora(aws.ToString(c.Engine)) && len(members) > 0 {
+		return nil, &types.InvalidDBClusterStateFault{Message: aws.String("Cluster cannot be deleted, it still contains DB instances in non-deleting state.")}
+	}
+	if sid := aws.ToString(params.FinalDBSnapshotIdentifier); sid != "" && !params.SkipFinalSnapshot {
+		if _, ok := f.clusterSnapshots[sid]; ok {
+			return nil, clusterSnapshotAlreadyExists(sid)
+		}
+		f.clusterSnapshots[sid] = f.newClusterSnapshot(c, sid, nil)
+	}
+	// Multi-AZ DB clusters delete their instances along with them.
+	for _, i := range members {
+		i.DBInstanceStatus = aws.String(StatusDeleting)
+		i.transitions = []string{statusDeleted}
+	}
+
+	c.Status = aws.String(StatusDeleting)
+	c.transitions = []string{statusDeleted}
+	out := c.copy()
+	return &rds.DeleteDBClusterOutput{DBCluster: &out}, nil
+}
+
+func (f *RDS) FailoverDBCluster(_ context.Context, params *rds.FailoverDBClusterInput, _ ...func(*rds.Options)) (*rds.FailoverDBClusterOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := aws.ToString(params.DBClusterIdentifier)
+	c, ok := f.clusters[id]
+	if !ok {
+		return nil, clusterNotFound(id)
+	}
+	if status := aws.ToString(c.Status); status != StatusAvailable {
+		return nil, invalidClusterState(id, status)
+	}
+
+	readers := []types.DBClusterMember{}
+	for _, m := range c.DBClusterMembers {
+		if !m.IsClusterWriter {
+			readers = append(readers, m)
+		}
+	}
+	if len(readers) == 0 {
+		return nil, invalidClusterState(id, "single-instance")
+	}
+	sortReaders(readers)
+	target := aws.ToString(readers[0].DBInstanceIdentifier)
+	if tid := aws.ToString(params.TargetDBInstanceIdentifier); tid != "" {
+		found := false
+		for _, m := range readers {
+			if aws.ToString(m.DBInstanceIdentifier) == tid {
+				found = true
+			}
+		}
+		if !found {
+			return nil, invalidParameterValue("The target instance %s is not a reader of DBCluster %s.", tid, id)
+		}
+		target = tid
+	}
+
+	for n := range c.DBClusterMembers {
+		c.DBClusterMembers[n].IsClusterWriter = aws.ToString(c.DBClusterMembers[n].DBInstanceIdentifier) == target
+	}
+	c.Status = aws.String(StatusFailingOver)
+	c.transitions = []string{StatusAvailable}
+	out := c.copy()
+	return &rds.FailoverDBClusterOutput{DBCluster: &out}, nil
+}
+
+func (f *RDS) RebootDBCluster(_ context.Context, params *rds.RebootDBClusterInput, _ ...func(*rds.Options)) (*rds.RebootDBClusterOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := aws.ToString(params.DBClusterIdentifier)
+	c, ok := f.clusters[id]
+	if !ok {
+		return nil, clusterNotFound(id)
+	}
+	if status := aws.ToString(c.Status); status != StatusAvailable {
+		return nil, invalidClusterState(id, status)
+	}
+
+	c.Status = aws.String(StatusRebooting)
+	c.transitions = []string{StatusAvailable}
+	out := c.copy()
+	return &rds.RebootDBClusterOutput{DBCluster: &out}, nil
+}
+
+func (f *RDS) DescribeDBClusters(_ context.Context, params *rds.DescribeDBClustersInput, _ ...func(*rds.Options)) (*rds.DescribeDBClustersOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.describe()
+
+	out := &rds.DescribeDBClustersOutput{}
+	if id := aws.ToString(params.DBClusterIdentifier); id != "" {
+		c, ok := f.clusters[id]
+		if !ok {
+			return nil, clusterNotFound(id)
+		}
+		out.DBClusters = append(out.DBClusters, c.copy())
+		return out, nil
+	}
+
+	ids := []string{}
+	for _, id := range sortedKeys(f.clusters) {
+		match, err := matchCluster(f.clusters[id], params.Filters)
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			ids = append(ids, id)
+		}
+	}
+	page, marker, err := paginate(ids, params.Marker, params.MaxRecords)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range page {
+		out.DBClusters = append(out.DBClusters, f.clusters[id].copy())
+	}
+	out.Marker = marker
+	return out, nil
+}
+
+// matchCluster reports whether the cluster matches all filters.
+func matchCluster(c *dbCluster, filters []types.Filter) (bool, error) {
+	for _, filter := range filters {
+		var value string
+		switch name := aws.ToString(filter.Name); name {
+		case "db-cluster-id":
+			value = aws.ToString(c.DBClusterIdentifier)
+		case "engine":
+			value = aws.ToString(c.Engine)
+		default:
+			return false, invalidParameterValue("Unrecognized filter name: %s", name)
+		}
+		if !contains(filter.Values, value) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (f *RDS) RestoreDBClusterToPointInTime(_ context.Context, params *rds.RestoreDBClusterToPointInTimeInput, _ ...func(*rds.Options)) (*rds.RestoreDBClusterToPointInTimeOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sid := aws.ToString(params.SourceDBClusterIdentifier)
+	if sid == "" {
+		return nil, missingParameter("SourceDBClusterIdentifier")
+	}
+	source, ok := f.clusters[sid]
+	if !ok {
+		return nil, clusterNotFound(sid)
+	}
+	if params.RestoreToTime != nil && params.UseLatestRestorableTime {
+		return nil, invalidParameterCombination("RestoreToTime and UseLatestRestorableTime cannot be used together.")
+	}
+
+	id := aws.ToString(params.DBClusterIdentifier)
+	if id == "" {
+		return nil, missingParameter("DBClusterIdentifier")
+	}
+	if _, ok := f.clusters[id]; ok {
+		return nil, &types.DBClusterAlreadyExistsFault{Message: aws.String(fmt.Sprintf("DBCluster %s already exists.", id))}
+	}
+
+	c := f.newCluster(id, source.Engine, source.EngineVersion)
+	c.EngineMode = source.EngineMode
+	c.MasterUsername = source.MasterUsername
+	c.DatabaseName = source.DatabaseName
+	c.DBClusterInstanceClass = source.DBClusterInstanceClass
+	c.AllocatedStorage = source.AllocatedStorage
+	c.StorageType = source.StorageType
+	c.Iops = source.Iops
+	c.Port = source.Port
+	c.DBSubnetGroup = source.DBSubnetGroup
+	c.TagList = params.Tags
+	if params.DBClusterInstanceClass != nil {
+		c.DBClusterInstanceClass = params.DBClusterInstanceClass
+	}
+	if params.DBSubnetGroupName != nil {
+		c.DBSubnetGroup = params.DBSubnetGroupName
+	}
+	if params.Iops != nil {
+		c.Iops = params.Iops
+	}
+
+	f.clusters[id] = c
+	if !isAurora(aws.ToString(c.Engine)) {
+		f.addMultiAZInstances(c)
+	}
+	out := c.copy()
+	return &rds.RestoreDBClusterToPointInTimeOutput{DBCluster: &out}, nil
+}
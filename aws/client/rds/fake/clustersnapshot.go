@@ -0,0 +1,313 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+func clusterSnapshotNotFound(id string) error {
+	return &types.DBClusterSnapshotNotFoundFault{Message: aws.String(fmt.Sprintf("DBClusterSnapshot %s not found.", id))}
+}
+
+func clusterSnapshotAlreadyExists(id string) error {
+	return &types.DBClusterSnapshotAlreadyExistsFault{Message: aws.String(fmt.Sprintf("Cannot create the cluster snapshot because one with the identifier %s already exists.", id))}
+}
+
+func invalidClusterSnapshotState(id, status string) error {
+	return &types.InvalidDBClusterSnapshotStateFault{Message: aws.String(fmt.Sprintf("DBClusterSnapshot %s is in %s state.", id, status))}
+}
+
+// newClusterSnapshot returns a snapshot of the cluster, which is created
+// when the returned snapshot is added to f.clusterSnapshots.
+func (f *RDS) newClusterSnapshot(c *dbCluster, id string, tags []types.Tag) *dbClusterSnapshot {
+	return &dbClusterSnapshot{DBClusterSnapshot: types.DBClusterSnapshot{
+		DBClusterSnapshotIdentifier: aws.String(id),
+		DBClusterSnapshotArn:        aws.String(f.arn("cluster-snapshot", id)),
+		DBClusterIdentifier:         c.DBClusterIdentifier,
+		Engine:                      c.Engine,
+		EngineVersion:               c.EngineVersion,
+		EngineMode:                  c.EngineMode,
+		AllocatedStorage:            aws.ToInt32(c.AllocatedStorage),
+		AvailabilityZones:           c.AvailabilityZones,
+		StorageEncrypted:            c.StorageEncrypted,
+		KmsKeyId:                    c.KmsKeyId,
+		MasterUsername:              c.MasterUsername,
+		Port:                        aws.ToInt32(c.Port),
+		ClusterCreateTime:           c.ClusterCreateTime,
+		SnapshotCreateTime:          now(),
+		SnapshotType:                aws.String(snapshotTypeManual),
+		Status:                      aws.String(StatusCreating),
+		TagList:                     tags,
+		VpcId:                       aws.String(FakeVpcID),
+	}, transitions: []string{StatusAvailable}, instanceClass: c.DBClusterInstanceClass}
+}
+
+func (f *RDS) CreateDBClusterSnapshot(_ context.Context, params *rds.CreateDBClusterSnapshotInput, _ ...func(*rds.Options)) (*rds.CreateDBClusterSnapshotOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := aws.ToString(params.DBClusterSnapshotIdentifier)
+	if id == "" {
+		return nil, missingParameter("DBClusterSnapshotIdentifier")
+	}
+	if _, ok := f.clusterSnapshots[id]; ok {
+		return nil, clusterSnapshotAlreadyExists(id)
+	}
+	cid := aws.ToString(params.DBClusterIdentifier)
+	c, ok := f.clusters[cid]
+	if !ok {
+		return nil, clusterNotFound(cid)
+	}
+	if status := aws.ToString(c.Status); status != StatusAvailable {
+		return nil, invalidClusterState(cid, status)
+	}
+
+	s := f.newClusterSnapshot(c, id, params.Tags)
+	f.clusterSnapshots[id] = s
+	out := s.DBClusterSnapshot
+	return &rds.CreateDBClusterSnapshotOutput{DBClusterSnapshot: &out}, nil
+}
+
+func (f *RDS) DescribeDBClusterSnapshots(_ context.Context, params *rds.DescribeDBClusterSnapshotsInput, _ ...func(*rds.Options)) (*rds.DescribeDBClusterSnapshotsOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.describe()
+
+	out := &rds.DescribeDBClusterSnapshotsOutput{}
+	if id := aws.ToString(params.DBClusterSnapshotIdentifier); id != "" {
+		s, ok := f.clusterSnapshots[id]
+		if !ok {
+			return nil, clusterSnapshotNotFound(id)
+		}
+		out.DBClusterSnapshots = append(out.DBClusterSnapshots, s.DBClusterSnapshot)
+		return out, nil
+	}
+
+	ids := []string{}
+	for _, id := range sortedKeys(f.clusterSnapshots) {
+		s := f.clusterSnapshots[id]
+		if cid := aws.ToString(params.DBClusterIdentifier); cid != "" && cid != aws.ToString(s.DBClusterIdentifier) {
+			continue
+		}
+		if t := aws.ToString(params.SnapshotType); t != "" && t != aws.ToString(s.SnapshotType) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	page, marker, err := paginate(ids, params.Marker, params.MaxRecords)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range page {
+		out.DBClusterSnapshots = append(out.DBClusterSnapshots, f.clusterSnapshots[id].DBClusterSnapshot)
+	}
+	out.Marker = marker
+	return out, nil
+}
+
+// sourceClusterSnapshot is sourceSnapshot for cluster snapshots.
+func (f *RDS) sourceClusterSnapshot(source string) (dbClusterSnapshot, error) {
+	f.mu.Lock()
+	region := f.region
+	id := source
+	if arn.IsARN(source) {
+		a, err := arn.Parse(source)
+		if err != nil {
+			f.mu.Unlock()
+			return dbClusterSnapshot{}, invalidParameterValue("Invalid cluster snapshot identifier: %s", source)
+		}
+		region = a.Region
+		id = a.Resource[strings.LastIndex(a.Resource, ":")+1:]
+	}
+	if region == f.region {
+		defer f.mu.Unlock()
+		s, ok := f.clusterSnapshots[id]
+		if !ok {
+			return dbClusterSnapshot{}, clusterSnapshotNotFound(source)
+		}
+		return dbClusterSnapshot{DBClusterSnapshot: s.DBClusterSnapshot, instanceClass: s.instanceClass}, nil
+	}
+
+	peer, ok := f.peers[region]
+	f.mu.Unlock()
+	if !ok {
+		return dbClusterSnapshot{}, clusterSnapshotNotFound(source)
+	}
+	return peer.sourceClusterSnapshot(id)
+}
+
+func (f *RDS) CopyDBClusterSnapshot(_ context.Context, params *rds.CopyDBClusterSnapshotInput, _ ...func(*rds.Options)) (*rds.CopyDBClusterSnapshotOutput, error) {
+	sid := aws.ToString(params.SourceDBClusterSnapshotIdentifier)
+	if sid == "" {
+		return nil, missingParameter("SourceDBClusterSnapshotIdentifier")
+	}
+	source, err := f.sourceClusterSnapshot(sid)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if status := aws.ToString(source.Status); status != StatusAvailable {
+		return nil, invalidClusterSnapshotState(sid, status)
+	}
+	id := aws.ToString(params.TargetDBClusterSnapshotIdentifier)
+	if id == "" {
+		return nil, missingParameter("TargetDBClusterSnapshotIdentifier")
+	}
+	if _, ok := f.clusterSnapshots[id]; ok {
+		return nil, clusterSnapshotAlreadyExists(id)
+	}
+	crossRegion := arn.IsARN(sid) && !strings.HasPrefix(sid, fmt.Sprintf("arn:aws:rds:%s:", f.region))
+	if crossRegion && source.StorageEncrypted && aws.ToString(params.KmsKeyId) == "" {
+		return nil, invalidParameterValue("KmsKeyId is required to copy an encrypted cluster snapshot to another region.")
+	}
+
+	s := &dbClusterSnapshot{DBClusterSnapshot: source.DBClusterSnapshot, transitions: []string{StatusAvailable}, instanceClass: source.instanceClass}
+	s.DBClusterSnapshotIdentifier = aws.String(id)
+	s.DBClusterSnapshotArn = aws.String(f.arn("cluster-snapshot", id))
+	s.SnapshotType = aws.String(snapshotTypeManual)
+	s.Status = aws.String(StatusCreating)
+	s.PercentProgress = 0
+	s.SourceDBClusterSnapshotArn = source.DBClusterSnapshotArn
+	s.SnapshotCreateTime = now()
+	s.TagList = params.Tags
+	if aws.ToBool(params.CopyTags) {
+		s.TagList = append(append([]types.Tag(nil), source.TagList...), params.Tags...)
+	}
+	if params.KmsKeyId != nil {
+		s.KmsKeyId = params.KmsKeyId
+	}
+
+	f.clusterSnapshots[id] = s
+	out := s.DBClusterSnapshot
+	return &rds.CopyDBClusterSnapshotOutput{DBClusterSnapshot: &out}, nil
+}
+
+func (f *RDS) DeleteDBClusterSnapshot(_ context.Context, params *rds.DeleteDBClusterSnapshotInput, _ ...func(*rds.Options)) (*rds.DeleteDBClusterSnapshotOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := aws.ToString(params.DBClusterSnapshotIdentifier)
+	s, ok := f.clusterSnapshots[id]
+	if !ok {
+		return nil, clusterSnapshotNotFound(id)
+	}
+	if status := aws.ToString(s.Status); status != StatusAvailable {
+		return nil, invalidClusterSnapshotState(id, status)
+	}
+
+	delete(f.clusterSnapshots, id)
+	out := s.DBClusterSnapshot
+	out.Status = aws.String("deleted")
+	return &rds.DeleteDBClusterSnapshotOutput{DBClusterSnapshot: &out}, nil
+}
+
+func (f *RDS) RestoreDBClusterFromSnapshot(_ context.Context, params *rds.RestoreDBClusterFromSnapshotInput, _ ...func(*rds.Options)) (*rds.RestoreDBClusterFromSnapshotOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sid := aws.ToString(params.SnapshotIdentifier)
+	if sid == "" {
+		return nil, missingParameter("SnapshotIdentifier")
+	}
+	s, ok := f.clusterSnapshots[sid]
+	if !ok {
+		return nil, clusterSnapshotNotFound(sid)
+	}
+	if status := aws.ToString(s.Status); status != StatusAvailable {
+		return nil, invalidClusterSnapshotState(sid, status)
+	}
+	id := aws.ToString(params.DBClusterIdentifier)
+	if id == "" {
+		return nil, missingParameter("DBClusterIdentifier")
+	}
+	if _, ok := f.clusters[id]; ok {
+		return nil, &types.DBClusterAlreadyExistsFault{Message: aws.String(fmt.Sprintf("DBCluster %s already exists.", id))}
+	}
+	engine := aws.ToString(params.Engine)
+	if engine == "" {
+		return nil, missingParameter("Engine")
+	}
+	if engine != aws.ToString(s.Engine) {
+		return nil, invalidParameterCombination("The engine %s does not match the engine %s of the snapshot.", engine, aws.ToString(s.Engine))
+	}
+
+	version := s.EngineVersion
+	if params.EngineVersion != nil {
+		version = params.EngineVersion
+	}
+	c := f.newCluster(id, params.Engine, version)
+	if params.EngineMode != nil {
+		c.EngineMode = params.EngineMode
+	}
+	c.MasterUsername = s.MasterUsername
+	c.DatabaseName = params.DatabaseName
+	c.AvailabilityZones = s.AvailabilityZones
+	if params.AvailabilityZones != nil {
+		c.AvailabilityZones = params.AvailabilityZones
+	}
+	c.AllocatedStorage = aws.Int32(s.AllocatedStorage)
+	c.StorageEncrypted = s.StorageEncrypted
+	c.KmsKeyId = s.KmsKeyId
+	if params.KmsKeyId != nil {
+		c.KmsKeyId = params.KmsKeyId
+	}
+	c.DBClusterInstanceClass = s.instanceClass
+	if params.DBClusterInstanceClass != nil {
+		c.DBClusterInstanceClass = params.DBClusterInstanceClass
+	}
+	c.StorageType = params.StorageType
+	c.Iops = params.Iops
+	c.DBSubnetGroup = params.DBSubnetGroupName
+	c.TagList = params.Tags
+	if params.Port != nil {
+		c.Port = params.Port
+	}
+	if params.DeletionProtection != nil {
+		c.DeletionProtection = params.DeletionProtection
+	}
+	if params.DBClusterParameterGroupName != nil {
+		c.DBClusterParameterGroup = params.DBClusterParameterGroupName
+	}
+	for _, sg := range params.VpcSecurityGroupIds {
+		c.VpcSecurityGroups = append(c.VpcSecurityGroups, types.VpcSecurityGroupMembership{
+			VpcSecurityGroupId: aws.String(sg),
+			Status:             aws.String("active"),
+		})
+	}
+	if err := setScaling(c, params.ScalingConfiguration, params.ServerlessV2ScalingConfiguration); err != nil {
+		return nil, err
+	}
+	if !isAurora(engine) && aws.ToString(c.DBClusterInstanceClass) == "" {
+		return nil, invalidParameterCombination("DBClusterInstanceClass is required for Multi-AZ DB clusters.")
+	}
+
+	f.clusters[id] = c
+	if !isAurora(engine) {
+		f.addMultiAZInstances(c)
+	}
+	out := c.copy()
+	return &rds.RestoreDBClusterFromSnapshotOutput{DBCluster: &out}, nil
+}
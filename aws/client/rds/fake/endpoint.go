@@ -0,0 +1,265 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+func endpointNotFound(id string) error {
+	return &types.DBClusterEndpointNotFoundFault{Message: aws.String(fmt.Sprintf("DBClusterEndpoint %s not found.", id))}
+}
+
+func invalidEndpointState(id, status string) error {
+	return &types.InvalidDBClusterEndpointStateFault{Message: aws.String(fmt.Sprintf("DBClusterEndpoint %s is in %s state.", id, status))}
+}
+
+// validateEndpointMembers checks the endpoint type and that the members
+// belong to the cluster.
+func validateEndpointMembers(c *dbCluster, endpointType string, static, excluded []string) error {
+	if endpointType != "READER" && endpointType != "ANY" {
+		return invalidParameterValue("Invalid endpoint type: %s", endpointType)
+	}
+	if len(static) > 0 && len(excluded) > 0 {
+		return invalidParameterCombination("StaticMembers and ExcludedMembers cannot be specified together.")
+	}
+	members := []string{}
+	for _, m := range c.DBClusterMembers {
+		members = append(members, aws.ToString(m.DBInstanceIdentifier))
+	}
+	for _, id := range append(append([]string{}, static...), excluded...) {
+		if !contains(members, id) {
+			return invalidParameterValue("DBInstance %s is not a member of DBCluster %s.", id, aws.ToString(c.DBClusterIdentifier))
+		}
+	}
+	return nil
+}
+
+func (f *RDS) CreateDBClusterEndpoint(_ context.Context, params *rds.CreateDBClusterEndpointInput, _ ...func(*rds.Options)) (*rds.CreateDBClusterEndpointOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := aws.ToString(params.DBClusterEndpointIdentifier)
+	if id == "" {
+		return nil, missingParameter("DBClusterEndpointIdentifier")
+	}
+	if _, ok := f.endpoints[id]; ok {
+		return nil, &types.DBClusterEndpointAlreadyExistsFault{Message: aws.String(fmt.Sprintf("DBClusterEndpoint %s already exists.", id))}
+	}
+	cid := aws.ToString(params.DBClusterIdentifier)
+	c, ok := f.clusters[cid]
+	if !ok {
+		return nil, clusterNotFound(cid)
+	}
+	if !isAurora(aws.ToString(c.Engine)) {
+		return nil, invalidParameterCombination("Custom endpoints are only supported by Aurora DB clusters.")
+	}
+	if status := aws.ToString(c.Status); status != StatusAvailable {
+		return nil, invalidClusterState(cid, status)
+	}
+	endpointType := strings.ToUpper(aws.ToString(params.EndpointType))
+	if err := validateEndpointMembers(c, endpointType, params.StaticMembers, params.ExcludedMembers); err != nil {
+		return nil, err
+	}
+
+	e := &dbClusterEndpoint{DBClusterEndpoint: types.DBClusterEndpoint{
+		DBClusterEndpointIdentifier:         aws.String(id),
+		DBClusterEndpointArn:                aws.String(f.arn("cluster-endpoint", id)),
+		DBClusterEndpointResourceIdentifier: aws.String(f.nextID("cluster-endpoint")),
+		DBClusterIdentifier:                 aws.String(cid),
+		Endpoint:                            aws.String(f.host(id, "cluster-custom-")),
+		EndpointType:                        aws.String("CUSTOM"),
+		CustomEndpointType:                  aws.String(endpointType),
+		StaticMembers:                       params.StaticMembers,
+		ExcludedMembers:                     params.ExcludedMembers,
+		Status:                              aws.String(StatusCreating),
+	}, transitions: []string{StatusAvailable}}
+
+	f.endpoints[id] = e
+	f.tags[aws.ToString(e.DBClusterEndpointArn)] = params.Tags
+	c.CustomEndpoints = append(c.CustomEndpoints, aws.ToString(e.Endpoint))
+	return &rds.CreateDBClusterEndpointOutput{
+		CustomEndpointType:                  e.CustomEndpointType,
+		DBClusterEndpointArn:                e.DBClusterEndpointArn,
+		DBClusterEndpointIdentifier:         e.DBClusterEndpointIdentifier,
+		DBClusterEndpointResourceIdentifier: e.DBClusterEndpointResourceIdentifier,
+		DBClusterIdentifier:                 e.DBClusterIdentifier,
+		Endpoint:                            e.Endpoint,
+		EndpointType:                        e.EndpointType,
+		ExcludedMembers:                     e.ExcludedMembers,
+		StaticMembers:                       e.StaticMembers,
+		Status:                              e.Status,
+	}, nil
+}
+
+func (f *RDS) ModifyDBClusterEndpoint(_ context.Context, params *rds.ModifyDBClusterEndpointInput, _ ...func(*rds.Options)) (*rds.ModifyDBClusterEndpointOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := aws.ToString(params.DBClusterEndpointIdentifier)
+	e, ok := f.endpoints[id]
+	if !ok {
+		return nil, endpointNotFound(id)
+	}
+	if status := aws.ToString(e.Status); status != StatusAvailable {
+		return nil, invalidEndpointState(id, status)
+	}
+	endpointType := aws.ToString(e.CustomEndpointType)
+	if params.EndpointType != nil {
+		endpointType = strings.ToUpper(aws.ToString(params.EndpointType))
+	}
+	static, excluded := e.StaticMembers, e.ExcludedMembers
+	// NOTE: Setting one member list replaces the other one.
+	if params.StaticMembers != nil || params.ExcludedMembers != nil {
+		static, excluded = params.StaticMembers, params.ExcludedMembers
+	}
+	if err := validateEndpointMembers(f.clusters[aws.ToString(e.DBClusterIdentifier)], endpointType, static, excluded); err != nil {
+		return nil, err
+	}
+
+	e.CustomEndpointType = aws.String(endpointType)
+	e.StaticMembers = static
+	e.ExcludedMembers = excluded
+	e.Status = aws.String(StatusModifying)
+	e.transitions = []string{StatusAvailable}
+	return &rds.ModifyDBClusterEndpointOutput{
+		CustomEndpointType:                  e.CustomEndpointType,
+		DBClusterEndpointArn:                e.DBClusterEndpointArn,
+		DBClusterEndpointIdentifier:         e.DBClusterEndpointIdentifier,
+		DBClusterEndpointResourceIdentifier: e.DBClusterEndpointResourceIdentifier,
+		DBClusterIdentifier:                 e.DBClusterIdentifier,
+		Endpoint:                            e.Endpoint,
+		EndpointType:                        e.EndpointType,
+		ExcludedMembers:                     e.ExcludedMembers,
+		StaticMembers:                       e.StaticMembers,
+		Status:                              e.Status,
+	}, nil
+}
+
+func (f *RDS) DeleteDBClusterEndpoint(_ context.Context, params *rds.DeleteDBClusterEndpointInput, _ ...func(*rds.Options)) (*rds.DeleteDBClusterEndpointOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := aws.ToString(params.DBClusterEndpointIdentifier)
+	e, ok := f.endpoints[id]
+	if !ok {
+		return nil, endpointNotFound(id)
+	}
+	if status := aws.ToString(e.Status); status != StatusAvailable {
+		return nil, invalidEndpointState(id, status)
+	}
+
+	e.Status = aws.String(StatusDeleting)
+	e.transitions = []string{statusDeleted}
+	return &rds.DeleteDBClusterEndpointOutput{
+		CustomEndpointType:                  e.CustomEndpointType,
+		DBClusterEndpointArn:                e.DBClusterEndpointArn,
+		DBClusterEndpointIdentifier:         e.DBClusterEndpointIdentifier,
+		DBClusterEndpointResourceIdentifier: e.DBClusterEndpointResourceIdentifier,
+		DBClusterIdentifier:                 e.DBClusterIdentifier,
+		Endpoint:                            e.Endpoint,
+		EndpointType:                        e.EndpointType,
+		ExcludedMembers:                     e.ExcludedMembers,
+		StaticMembers:                       e.StaticMembers,
+		Status:                              e.Status,
+	}, nil
+}
+
+// DescribeDBClusterEndpoints returns the custom endpoints only, the writer
+// and reader endpoints are reported by DescribeDBClusters.
+func (f *RDS) DescribeDBClusterEndpoints(_ context.Context, params *rds.DescribeDBClusterEndpointsInput, _ ...func(*rds.Options)) (*rds.DescribeDBClusterEndpointsOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.describe()
+
+	out := &rds.DescribeDBClusterEndpointsOutput{}
+	if id := aws.ToString(params.DBClusterEndpointIdentifier); id != "" {
+		e, ok := f.endpoints[id]
+		if !ok {
+			return nil, endpointNotFound(id)
+		}
+		out.DBClusterEndpoints = append(out.DBClusterEndpoints, e.DBClusterEndpoint)
+		return out, nil
+	}
+
+	ids := []string{}
+	for _, id := range sortedKeys(f.endpoints) {
+		e := f.endpoints[id]
+		if cid := aws.ToString(params.DBClusterIdentifier); cid != "" && cid != aws.ToString(e.DBClusterIdentifier) {
+			continue
+		}
+		match, err := matchEndpoint(e, params.Filters)
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			ids = append(ids, id)
+		}
+	}
+	page, marker, err := paginate(ids, params.Marker, params.MaxRecords)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range page {
+		out.DBClusterEndpoints = append(out.DBClusterEndpoints, f.endpoints[id].DBClusterEndpoint)
+	}
+	out.Marker = marker
+	return out, nil
+}
+
+// matchEndpoint reports whether the endpoint matches all filters.
+func matchEndpoint(e *dbClusterEndpoint, filters []types.Filter) (bool, error) {
+	for _, filter := range filters {
+		var value string
+		switch name := aws.ToString(filter.Name); name {
+		case "db-cluster-endpoint-type":
+			value = strings.ToLower(aws.ToString(e.EndpointType))
+		case "db-cluster-endpoint-custom-type":
+			value = strings.ToLower(aws.ToString(e.CustomEndpointType))
+		case "db-cluster-endpoint-status":
+			value = aws.ToString(e.Status)
+		default:
+			return false, invalidParameterValue("Unrecognized filter name: %s", name)
+		}
+		if !contains(filter.Values, value) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// removeEndpoints removes the custom endpoints of a deleted cluster.
+func (f *RDS) removeEndpoints(cid string) {
+	for id, e := range f.endpoints {
+		if aws.ToString(e.DBClusterIdentifier) == cid {
+			delete(f.endpoints, id)
+		}
+	}
+}
+
+// removeCustomEndpoint removes a deleted endpoint from its cluster.
+func (f *RDS) removeCustomEndpoint(e *dbClusterEndpoint) {
+	c, ok := f.clusters[aws.ToString(e.DBClusterIdentifier)]
+	if !ok {
+		return
+	}
+	c.CustomEndpoints = without(c.CustomEndpoints, aws.ToString(e.Endpoint))
+}
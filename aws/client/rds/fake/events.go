@@ -0,0 +1,167 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+// defaultEventDuration is the window of DescribeEvents without StartTime.
+const defaultEventDuration = time.Hour
+
+type eventSpec struct {
+	category string
+	message  string
+}
+
+// transition is a status change, from the first status to the second.
+type transition [2]string
+
+// instanceEvents, clusterEvents and snapshotEvents are the events recorded
+// when a resource finishes a transition.
+var (
+	instanceEvents = map[transition]eventSpec{
+		{StatusCreating, StatusAvailable}:    {"creation", "DB instance created"},
+		{StatusRebooting, StatusAvailable}:   {"availability", "DB instance restarted"},
+		{StatusModifying, StatusAvailable}:   {"configuration change", "Finished applying modification to DB instance"},
+		{StatusFailingOver, StatusAvailable}: {"failover", "Multi-AZ instance failover completed"},
+		{StatusStopping, StatusStopped}:      {"notification", "DB instance stopped"},
+		{StatusStarting, StatusAvailable}:    {"notification", "DB instance started"},
+		{StatusDeleting, statusDeleted}:      {"deletion", "DB instance deleted"},
+	}
+	clusterEvents = map[transition]eventSpec{
+		{StatusCreating, StatusAvailable}:    {"creation", "DB cluster created"},
+		{StatusRebooting, StatusAvailable}:   {"notification", "DB cluster restarted"},
+		{StatusModifying, StatusAvailable}:   {"configuration change", "Finished applying modification to DB cluster"},
+		{StatusFailingOver, StatusAvailable}: {"failover", "Completed failover to DB instance"},
+		{StatusStopping, StatusStopped}:      {"notification", "DB cluster stopped"},
+		{StatusStarting, StatusAvailable}:    {"notification", "DB cluster started"},
+		{StatusDeleting, statusDeleted}:      {"deletion", "DB cluster deleted"},
+	}
+	snapshotEvents = map[transition]eventSpec{
+		{StatusCreating, StatusAvailable}: {"creation", "Manual snapshot created"},
+		{StatusDeleting, statusDeleted}:   {"deletion", "Manual snapshot deleted"},
+	}
+)
+
+// sourceResources are the ARN resource names of the source types.
+var sourceResources = map[types.SourceType]string{
+	types.SourceTypeDbInstance:        "db",
+	types.SourceTypeDbCluster:         "cluster",
+	types.SourceTypeDbSnapshot:        "snapshot",
+	types.SourceTypeDbClusterSnapshot: "cluster-snapshot",
+}
+
+// transitionEvent records the event of a transition, if there is one.
+func (f *RDS) transitionEvent(events map[transition]eventSpec, sourceType types.SourceType, id, from, to string) {
+	if e, ok := events[transition{from, to}]; ok {
+		f.recordEvent(sourceType, id, e.message, e.category)
+	}
+}
+
+func (f *RDS) recordEvent(sourceType types.SourceType, id, message string, categories ...string) {
+	f.events = append(f.events, types.Event{
+		SourceType:       sourceType,
+		SourceIdentifier: aws.String(id),
+		SourceArn:        aws.String(f.arn(sourceResources[sourceType], id)),
+		Message:          aws.String(message),
+		EventCategories:  categories,
+		Date:             now(),
+	})
+}
+
+// RecordEvent records an event of a source, such as the failure of an
+// operation RDS reports asynchronously.
+func (f *RDS) RecordEvent(sourceType types.SourceType, id, message string, categories ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.recordEvent(sourceType, id, message, categories...)
+}
+
+func (f *RDS) DescribeEvents(_ context.Context, params *rds.DescribeEventsInput, _ ...func(*rds.Options)) (*rds.DescribeEventsOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.describe()
+
+	id := aws.ToString(params.SourceIdentifier)
+	if id != "" && params.SourceType == "" {
+		return nil, invalidParameterCombination("Cannot specify source identifier without source type")
+	}
+	if _, ok := sourceResources[params.SourceType]; params.SourceType != "" && !ok {
+		return nil, invalidParameterValue("Invalid source type: %s", params.SourceType)
+	}
+	end := aws.ToTime(params.EndTime)
+	if params.EndTime == nil {
+		end = *now()
+	}
+	start := aws.ToTime(params.StartTime)
+	if params.StartTime == nil {
+		duration := defaultEventDuration
+		if params.Duration != nil {
+			duration = time.Duration(*params.Duration) * time.Minute
+		}
+		start = end.Add(-duration)
+	}
+	if end.Before(start) {
+		return nil, invalidParameterCombination("The end time must be after the start time")
+	}
+
+	events := []types.Event{}
+	for _, e := range f.events {
+		if params.SourceType != "" && e.SourceType != params.SourceType {
+			continue
+		}
+		if id != "" && aws.ToString(e.SourceIdentifier) != id {
+			continue
+		}
+		if e.Date.Before(start) || e.Date.After(end) {
+			continue
+		}
+		if len(params.EventCategories) > 0 && !matchCategories(e.EventCategories, params.EventCategories) {
+			continue
+		}
+		events = append(events, e)
+	}
+
+	keys := make([]string, len(events))
+	for i := range events {
+		keys[i] = strconv.Itoa(i)
+	}
+	page, marker, err := paginate(keys, params.Marker, params.MaxRecords)
+	if err != nil {
+		return nil, err
+	}
+	out := &rds.DescribeEventsOutput{Marker: marker}
+	for _, k := range page {
+		n, _ := strconv.Atoi(k)
+		out.Events = append(out.Events, events[n])
+	}
+	return out, nil
+}
+
+func matchCategories(categories, wanted []string) bool {
+	for _, c := range categories {
+		if contains(wanted, c) {
+			return true
+		}
+	}
+	return false
+}
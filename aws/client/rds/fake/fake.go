@@ -0,0 +1,425 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fake is an in-memory implementation of the RDS API for testing
+// code built on package rds without AWS.
+//
+// Resources go through the same statuses as on AWS, e.g. creating, then
+// available, then deleting before they disappear. A transition happens when
+// Advance is called, or before every Describe call once SetAutoAdvance is
+// enabled, so tests decide how long an operation takes.
+package fake
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/aws/smithy-go"
+)
+
+const (
+	DefaultRegion  = "us-east-1"
+	DefaultAccount = "123456789012"
+)
+
+const (
+	StatusCreating    = "creating"
+	StatusAvailable   = "available"
+	StatusDeleting    = "deleting"
+	StatusRebooting   = "rebooting"
+	StatusFailingOver = "failing-over"
+	StatusModifying   = "modifying"
+	StatusStopping    = "stopping"
+	StatusStopped     = "stopped"
+	StatusStarting    = "starting"
+
+	// statusDeleted removes the resource when it is reached.
+	statusDeleted = ""
+)
+
+// RDS is an in-memory RDS API of a single account and region. It is safe for
+// concurrent use.
+type RDS struct {
+	mu          sync.Mutex
+	region      string
+	account     string
+	autoAdvance bool
+	sequence    int
+
+	instances    map[string]*dbInstance
+	clusters     map[string]*dbCluster
+	subnetGroups map[string]*types.DBSubnetGroup
+	snapshots    map[string]*dbSnapshot
+	// clusterSnapshots are the DB cluster snapshots.
+	clusterSnapshots map[string]*dbClusterSnapshot
+	// endpoints are the custom DB cluster endpoints.
+	endpoints map[string]*dbClusterEndpoint
+	// instanceParameterGroups and clusterParameterGroups hold the groups
+	// created by users and the default groups used so far.
+	instanceParameterGroups map[string]*dbParameterGroup
+	clusterParameterGroups  map[string]*dbParameterGroup
+	// tags are the tags of the resources whose RDS type has no tag list,
+	// by ARN.
+	tags map[string][]types.Tag
+	// events are the events of all sources, oldest first.
+	events []types.Event
+
+	// globals are shared with the peers.
+	globals *globalClusters
+
+	// peers are the fakes of other regions, see Connect.
+	peers map[string]*RDS
+}
+
+type dbInstance struct {
+	types.DBInstance
+	transitions []string
+	// logs are the log files by name, see logFiles.
+	logs map[string]*dbLogFile
+}
+
+type dbCluster struct {
+	types.DBCluster
+	transitions []string
+}
+
+type dbClusterEndpoint struct {
+	types.DBClusterEndpoint
+	transitions []string
+}
+
+type dbSnapshot struct {
+	types.DBSnapshot
+	transitions []string
+	// restore are the accounts allowed to restore the snapshot.
+	restore []string
+	// instanceClass is the default class of restored instances.
+	instanceClass *string
+}
+
+type dbClusterSnapshot struct {
+	types.DBClusterSnapshot
+	transitions []string
+	// instanceClass is the default class of restored Multi-AZ DB clusters.
+	instanceClass *string
+}
+
+func New() *RDS {
+	return &RDS{
+		region:                  DefaultRegion,
+		account:                 DefaultAccount,
+		instances:               map[string]*dbInstance{},
+		clusters:                map[string]*dbCluster{},
+		subnetGroups:            map[string]*types.DBSubnetGroup{},
+		snapshots:               map[string]*dbSnapshot{},
+		clusterSnapshots:        map[string]*dbClusterSnapshot{},
+		endpoints:               map[string]*dbClusterEndpoint{},
+		instanceParameterGroups: map[string]*dbParameterGroup{},
+		clusterParameterGroups:  map[string]*dbParameterGroup{},
+		tags:                    map[string][]types.Tag{},
+		globals:                 newGlobalClusters(),
+		peers:                   map[string]*RDS{},
+	}
+}
+
+// SetRegion sets the region used in ARNs and endpoints.
+func (f *RDS) SetRegion(region string) *RDS {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.region = region
+	return f
+}
+
+// SetAccount sets the account used in ARNs.
+func (f *RDS) SetAccount(account string) *RDS {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.account = account
+	return f
+}
+
+// SetAutoAdvance makes every Describe call advance all resources by one
+// step first, which lets pollers make progress on their own.
+func (f *RDS) SetAutoAdvance(enable bool) *RDS {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.autoAdvance = enable
+	return f
+}
+
+// Connect makes the fakes reachable from each other by region, e.g. for
+// cross-region copies, and shares their global clusters. Every fake must
+// have its own region.
+func Connect(fakes ...*RDS) {
+	if len(fakes) == 0 {
+		return
+	}
+	fakes[0].mu.Lock()
+	globals := fakes[0].globals
+	fakes[0].mu.Unlock()
+	for _, f := range fakes {
+		f.mu.Lock()
+		other := f.globals
+		f.globals = globals
+		f.mu.Unlock()
+		globals.merge(other)
+	}
+
+	for _, f := range fakes {
+		for _, peer := range fakes {
+			if f == peer {
+				continue
+			}
+			peer.mu.Lock()
+			region := peer.region
+			peer.mu.Unlock()
+
+			f.mu.Lock()
+			f.peers[region] = peer
+			f.mu.Unlock()
+		}
+	}
+}
+
+// Advance moves every resource in transition to its next status. Resources
+// which finished deleting are removed.
+func (f *RDS) Advance() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.advance()
+}
+
+// Settle advances until no resource is in transition.
+func (f *RDS) Settle() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for f.inTransition() {
+		f.advance()
+	}
+}
+
+func (f *RDS) inTransition() bool {
+	for _, i := range f.instances {
+		if len(i.transitions) > 0 {
+			return true
+		}
+	}
+	for _, c := range f.clusters {
+		if len(c.transitions) > 0 {
+			return true
+		}
+	}
+	for _, s := range f.snapshots {
+		if len(s.transitions) > 0 {
+			return true
+		}
+	}
+	for _, s := range f.clusterSnapshots {
+		if len(s.transitions) > 0 {
+			return true
+		}
+	}
+	for _, e := range f.endpoints {
+		if len(e.transitions) > 0 {
+			return true
+		}
+	}
+	return f.globals.inTransition()
+}
+
+func (f *RDS) advance() {
+	for id, i := range f.instances {
+		if len(i.transitions) == 0 {
+			continue
+		}
+		next := i.transitions[0]
+		i.transitions = i.transitions[1:]
+		f.transitionEvent(instanceEvents, types.SourceTypeDbInstance, id, aws.ToString(i.DBInstanceStatus), next)
+		if next == statusDeleted {
+			delete(f.instances, id)
+			f.removeMember(i)
+			f.removeReplicas(i)
+			continue
+		}
+		i.DBInstanceStatus = aws.String(next)
+	}
+	for id, c := range f.clusters {
+		if len(c.transitions) == 0 {
+			continue
+		}
+		next := c.transitions[0]
+		c.transitions = c.transitions[1:]
+		f.transitionEvent(clusterEvents, types.SourceTypeDbCluster, id, aws.ToString(c.Status), next)
+		if next == statusDeleted {
+			delete(f.clusters, id)
+			f.removeEndpoints(id)
+			continue
+		}
+		c.Status = aws.String(next)
+	}
+	for id, s := range f.snapshots {
+		if len(s.transitions) == 0 {
+			continue
+		}
+		next := s.transitions[0]
+		s.transitions = s.transitions[1:]
+		f.transitionEvent(snapshotEvents, types.SourceTypeDbSnapshot, id, aws.ToString(s.Status), next)
+		if next == statusDeleted {
+			delete(f.snapshots, id)
+			continue
+		}
+		s.Status = aws.String(next)
+		if next == StatusAvailable {
+			s.PercentProgress = 100
+		}
+	}
+	for id, s := range f.clusterSnapshots {
+		if len(s.transitions) == 0 {
+			continue
+		}
+		next := s.transitions[0]
+		s.transitions = s.transitions[1:]
+		f.transitionEvent(snapshotEvents, types.SourceTypeDbClusterSnapshot, id, aws.ToString(s.Status), next)
+		if next == statusDeleted {
+			delete(f.clusterSnapshots, id)
+			continue
+		}
+		s.Status = aws.String(next)
+		if next == StatusAvailable {
+			s.PercentProgress = 100
+		}
+	}
+	for id, e := range f.endpoints {
+		if len(e.transitions) == 0 {
+			continue
+		}
+		next := e.transitions[0]
+		e.transitions = e.transitions[1:]
+		if next == statusDeleted {
+			delete(f.endpoints, id)
+			f.removeCustomEndpoint(e)
+			continue
+		}
+		e.Status = aws.String(next)
+	}
+	f.globals.advance()
+}
+
+// describe is called at the start of every Describe operation.
+func (f *RDS) describe() {
+	if f.autoAdvance {
+		f.advance()
+	}
+}
+
+func (f *RDS) nextID(prefix string) string {
+	f.sequence++
+	return fmt.Sprintf("%s-%08d", prefix, f.sequence)
+}
+
+func (f *RDS) arn(resource, id string) string {
+	return fmt.Sprintf("arn:aws:rds:%s:%s:%s:%s", f.region, f.account, resource, id)
+}
+
+func (f *RDS) host(id, kind string) string {
+	return fmt.Sprintf("%s.%sfake.%s.rds.amazonaws.com", id, kind, f.region)
+}
+
+func defaultPort(engine string) int32 {
+	if strings.Contains(engine, "postgres") {
+		return 5432
+	}
+	if strings.HasPrefix(engine, "sqlserver") {
+		return 1433
+	}
+	if strings.HasPrefix(engine, "oracle") {
+		return 1521
+	}
+	return 3306
+}
+
+func isAurora(engine string) bool {
+	return strings.HasPrefix(engine, "aurora")
+}
+
+func now() *time.Time {
+	t := time.Now().UTC()
+	return &t
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+const (
+	defaultMaxRecords = 100
+	minMaxRecords     = 20
+)
+
+// paginate returns the page of keys selected by marker and maxRecords, and
+// the marker of the next page if there is one.
+func paginate(keys []string, marker *string, maxRecords *int32) ([]string, *string, error) {
+	size := defaultMaxRecords
+	if maxRecords != nil {
+		size = int(*maxRecords)
+		if size < minMaxRecords || size > defaultMaxRecords {
+			return nil, nil, invalidParameterValue("Invalid value %d for MaxRecords. Must be between %d and %d", size, minMaxRecords, defaultMaxRecords)
+		}
+	}
+	start := 0
+	if m := aws.ToString(marker); m != "" {
+		n, err := strconv.Atoi(m)
+		if err != nil || n < 0 || n > len(keys) {
+			return nil, nil, invalidParameterValue("Invalid marker: %s", m)
+		}
+		start = n
+	}
+	end := start + size
+	if end >= len(keys) {
+		return keys[start:], nil, nil
+	}
+	return keys[start:end], aws.String(strconv.Itoa(end)), nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func invalidParameterValue(format string, args ...interface{}) error {
+	return &smithy.GenericAPIError{Code: "InvalidParameterValue", Message: fmt.Sprintf(format, args...), Fault: smithy.FaultClient}
+}
+
+func invalidParameterCombination(format string, args ...interface{}) error {
+	return &smithy.GenericAPIError{Code: "InvalidParameterCombination", Message: fmt.Sprintf(format, args...), Fault: smithy.FaultClient}
+}
+
+func missingParameter(name string) error {
+	return &smithy.GenericAPIError{Code: "MissingParameter", Message: fmt.Sprintf("%s is required", name), Fault: smithy.FaultClient}
+}
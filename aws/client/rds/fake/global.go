@@ -0,0 +1,479 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+// globalClusters are the Aurora global databases. They are not regional,
+// so the fakes joined by Connect share them.
+// NOTE: The lock of a fake may be held while locking globalClusters, never
+// the other way around.
+type globalClusters struct {
+	mu       sync.Mutex
+	sequence int
+	clusters map[string]*globalCluster
+}
+
+type globalCluster struct {
+	types.GlobalCluster
+	transitions []string
+}
+
+func newGlobalClusters() *globalClusters {
+	return &globalClusters{clusters: map[string]*globalCluster{}}
+}
+
+func globalClusterNotFound(id string) error {
+	return &types.GlobalClusterNotFoundFault{Message: aws.String(fmt.Sprintf("GlobalCluster %s not found.", id))}
+}
+
+func invalidGlobalClusterState(format string, args ...interface{}) error {
+	return &types.InvalidGlobalClusterStateFault{Message: aws.String(fmt.Sprintf(format, args...))}
+}
+
+// merge moves the global clusters of other into g.
+func (g *globalClusters) merge(other *globalClusters) {
+	if g == other {
+		return
+	}
+	other.mu.Lock()
+	clusters := other.clusters
+	other.mu.Unlock()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for id, c := range clusters {
+		g.clusters[id] = c
+	}
+}
+
+func (g *globalClusters) inTransition() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, c := range g.clusters {
+		if len(c.transitions) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *globalClusters) advance() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for id, c := range g.clusters {
+		if len(c.transitions) == 0 {
+			continue
+		}
+		next := c.transitions[0]
+		c.transitions = c.transitions[1:]
+		if next == statusDeleted {
+			delete(g.clusters, id)
+			continue
+		}
+		c.Status = aws.String(next)
+		if next == StatusAvailable {
+			c.FailoverState = nil
+		}
+	}
+}
+
+// copy returns the global cluster with the readers of its writer, which
+// are the other members.
+func (c *globalCluster) copy() types.GlobalCluster {
+	out := c.GlobalCluster
+	out.GlobalClusterMembers = []types.GlobalClusterMember{}
+	readers := []string{}
+	for _, m := range c.GlobalClusterMembers {
+		if !m.IsWriter {
+			readers = append(readers, aws.ToString(m.DBClusterArn))
+		}
+	}
+	for _, m := range c.GlobalClusterMembers {
+		m.Readers = nil
+		if m.IsWriter {
+			m.Readers = readers
+		}
+		out.GlobalClusterMembers = append(out.GlobalClusterMembers, m)
+	}
+	return out
+}
+
+func (c *globalCluster) member(clusterArn string) (int, bool) {
+	for n, m := range c.GlobalClusterMembers {
+		if aws.ToString(m.DBClusterArn) == clusterArn {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// memberOf returns the global cluster of the DB cluster, if any. It is
+// called with g.mu held.
+func (g *globalClusters) memberOf(clusterArn string) (*globalCluster, bool) {
+	for _, c := range g.clusters {
+		if _, ok := c.member(clusterArn); ok {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// joinGlobalCluster adds the DB cluster to the global cluster, as the writer
+// when it is the first member. It is called with f.mu held.
+func (f *RDS) joinGlobalCluster(id string, c *dbCluster) error {
+	f.globals.mu.Lock()
+	defer f.globals.mu.Unlock()
+
+	g, ok := f.globals.clusters[id]
+	if !ok {
+		return globalClusterNotFound(id)
+	}
+	if status := aws.ToString(g.Status); status != StatusAvailable {
+		return invalidGlobalClusterState("GlobalCluster %s is in %s state.", id, status)
+	}
+	if aws.ToString(g.Engine) != aws.ToString(c.Engine) {
+		return invalidParameterCombination("The engine of DBCluster %s must be %s to join GlobalCluster %s.", aws.ToString(c.DBClusterIdentifier), aws.ToString(g.Engine), id)
+	}
+	if c.EngineVersion == nil {
+		c.EngineVersion = g.EngineVersion
+	}
+	g.GlobalClusterMembers = append(g.GlobalClusterMembers, types.GlobalClusterMember{
+		DBClusterArn: c.DBClusterArn,
+		IsWriter:     len(g.GlobalClusterMembers) == 0,
+	})
+	return nil
+}
+
+// checkGlobalMember fails when the DB cluster is still a member of a global
+// cluster. It is called with f.mu held.
+func (f *RDS) checkGlobalMember(c *dbCluster) error {
+	f.globals.mu.Lock()
+	defer f.globals.mu.Unlock()
+	if g, ok := f.globals.memberOf(aws.ToString(c.DBClusterArn)); ok {
+		return invalidClusterState(aws.ToString(c.DBClusterIdentifier), "global-cluster-member of "+aws.ToString(g.GlobalClusterIdentifier))
+	}
+	return nil
+}
+
+// clusterRegion returns the fake of the region of clusterArn and the
+// identifier of the cluster.
+func (f *RDS) clusterRegion(clusterArn string) (*RDS, string, bool) {
+	a, err := arn.Parse(clusterArn)
+	if err != nil {
+		return nil, "", false
+	}
+	id := a.Resource[strings.LastIndex(a.Resource, ":")+1:]
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if a.Region == f.region {
+		return f, id, true
+	}
+	peer, ok := f.peers[a.Region]
+	return peer, id, ok
+}
+
+// sourceCluster returns a copy of the DB cluster of clusterArn, which is
+// looked up in the peers when it belongs to another region.
+func (f *RDS) sourceCluster(clusterArn string) (types.DBCluster, error) {
+	r, id, ok := f.clusterRegion(clusterArn)
+	if !ok {
+		return types.DBCluster{}, clusterNotFound(clusterArn)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.clusters[id]
+	if !ok {
+		return types.DBCluster{}, clusterNotFound(clusterArn)
+	}
+	return c.copy(), nil
+}
+
+// detachCluster makes the DB cluster of clusterArn a standalone cluster.
+func (f *RDS) detachCluster(clusterArn string) {
+	r, id, ok := f.clusterRegion(clusterArn)
+	if !ok {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if c, ok := r.clusters[id]; ok && aws.ToString(c.Status) == StatusAvailable {
+		c.Status = aws.String(StatusModifying)
+		c.transitions = []string{StatusAvailable}
+	}
+}
+
+func (f *RDS) CreateGlobalCluster(_ context.Context, params *rds.CreateGlobalClusterInput, _ ...func(*rds.Options)) (*rds.CreateGlobalClusterOutput, error) {
+	id := aws.ToString(params.GlobalClusterIdentifier)
+	if id == "" {
+		return nil, missingParameter("GlobalClusterIdentifier")
+	}
+	g := &globalCluster{GlobalCluster: types.GlobalCluster{
+		GlobalClusterIdentifier: aws.String(id),
+		Engine:                  params.Engine,
+		EngineVersion:           params.EngineVersion,
+		DatabaseName:            params.DatabaseName,
+		DeletionProtection:      aws.Bool(aws.ToBool(params.DeletionProtection)),
+		StorageEncrypted:        aws.Bool(aws.ToBool(params.StorageEncrypted)),
+		Status:                  aws.String(StatusCreating),
+		GlobalClusterMembers:    []types.GlobalClusterMember{},
+	}, transitions: []string{StatusAvailable}}
+
+	if sid := aws.ToString(params.SourceDBClusterIdentifier); sid != "" {
+		if !arn.IsARN(sid) {
+			return nil, invalidParameterValue("SourceDBClusterIdentifier must be the ARN of a DB cluster: %s", sid)
+		}
+		source, err := f.sourceCluster(sid)
+		if err != nil {
+			return nil, err
+		}
+		if !isAurora(aws.ToString(source.Engine)) {
+			return nil, invalidParameterCombination("Global clusters are only supported by Aurora DB clusters.")
+		}
+		if status := aws.ToString(source.Status); status != StatusAvailable {
+			return nil, invalidClusterState(aws.ToString(source.DBClusterIdentifier), status)
+		}
+		if params.Engine != nil || params.EngineVersion != nil || params.DatabaseName != nil || params.StorageEncrypted != nil {
+			return nil, invalidParameterCombination("Engine, EngineVersion, DatabaseName and StorageEncrypted come from the SourceDBClusterIdentifier.")
+		}
+		g.Engine = source.Engine
+		g.EngineVersion = source.EngineVersion
+		g.DatabaseName = source.DatabaseName
+		g.StorageEncrypted = aws.Bool(source.StorageEncrypted)
+		g.GlobalClusterMembers = append(g.GlobalClusterMembers, types.GlobalClusterMember{
+			DBClusterArn: source.DBClusterArn,
+			IsWriter:     true,
+		})
+	} else if !isAurora(aws.ToString(params.Engine)) {
+		return nil, invalidParameterCombination("Global clusters are only supported by Aurora engines.")
+	}
+
+	f.mu.Lock()
+	account := f.account
+	globals := f.globals
+	f.mu.Unlock()
+
+	globals.mu.Lock()
+	defer globals.mu.Unlock()
+	if _, ok := globals.clusters[id]; ok {
+		return nil, &types.GlobalClusterAlreadyExistsFault{Message: aws.String(fmt.Sprintf("GlobalCluster %s already exists.", id))}
+	}
+	for _, m := range g.GlobalClusterMembers {
+		if other, ok := globals.memberOf(aws.ToString(m.DBClusterArn)); ok {
+			return nil, invalidParameterCombination("DBCluster %s is already a member of GlobalCluster %s.", aws.ToString(m.DBClusterArn), aws.ToString(other.GlobalClusterIdentifier))
+		}
+	}
+	globals.sequence++
+	g.GlobalClusterArn = aws.String(fmt.Sprintf("arn:aws:rds::%s:global-cluster:%s", account, id))
+	g.GlobalClusterResourceId = aws.String(fmt.Sprintf("cluster-%08d", globals.sequence))
+	globals.clusters[id] = g
+	out := g.copy()
+	return &rds.CreateGlobalClusterOutput{GlobalCluster: &out}, nil
+}
+
+func (f *RDS) DeleteGlobalCluster(_ context.Context, params *rds.DeleteGlobalClusterInput, _ ...func(*rds.Options)) (*rds.DeleteGlobalClusterOutput, error) {
+	f.mu.Lock()
+	globals := f.globals
+	f.mu.Unlock()
+
+	globals.mu.Lock()
+	defer globals.mu.Unlock()
+	id := aws.ToString(params.GlobalClusterIdentifier)
+	g, ok := globals.clusters[id]
+	if !ok {
+		return nil, globalClusterNotFound(id)
+	}
+	if status := aws.ToString(g.Status); status == StatusDeleting {
+		return nil, invalidGlobalClusterState("GlobalCluster %s is in %s state.", id, status)
+	}
+	if aws.ToBool(g.DeletionProtection) {
+		return nil, invalidParameterCombination("Cannot delete protected GlobalCluster, please disable deletion protection and try again.")
+	}
+	if len(g.GlobalClusterMembers) > 0 {
+		return nil, invalidGlobalClusterState("GlobalCluster %s cannot be deleted, it still has %d member(s).", id, len(g.GlobalClusterMembers))
+	}
+	g.Status = aws.String(StatusDeleting)
+	g.transitions = []string{statusDeleted}
+	out := g.copy()
+	return &rds.DeleteGlobalClusterOutput{GlobalCluster: &out}, nil
+}
+
+func (f *RDS) DescribeGlobalClusters(_ context.Context, params *rds.DescribeGlobalClustersInput, _ ...func(*rds.Options)) (*rds.DescribeGlobalClustersOutput, error) {
+	f.mu.Lock()
+	f.describe()
+	globals := f.globals
+	f.mu.Unlock()
+
+	globals.mu.Lock()
+	defer globals.mu.Unlock()
+	out := &rds.DescribeGlobalClustersOutput{}
+	if id := aws.ToString(params.GlobalClusterIdentifier); id != "" {
+		g, ok := globals.clusters[id]
+		if !ok {
+			return nil, globalClusterNotFound(id)
+		}
+		out.GlobalClusters = append(out.GlobalClusters, g.copy())
+		return out, nil
+	}
+
+	keys := []string{}
+	for _, id := range sortedKeys(globals.clusters) {
+		match, err := matchGlobalCluster(globals.clusters[id], params.Filters)
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			keys = append(keys, id)
+		}
+	}
+	page, marker, err := paginate(keys, params.Marker, params.MaxRecords)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range page {
+		out.GlobalClusters = append(out.GlobalClusters, globals.clusters[id].copy())
+	}
+	out.Marker = marker
+	return out, nil
+}
+
+func matchGlobalCluster(g *globalCluster, filters []types.Filter) (bool, error) {
+	for _, filter := range filters {
+		switch name := aws.ToString(filter.Name); name {
+		case "db-cluster-id":
+			found := false
+			for _, m := range g.GlobalClusterMembers {
+				for _, v := range filter.Values {
+					found = found || aws.ToString(m.DBClusterArn) == v
+				}
+			}
+			if !found {
+				return false, nil
+			}
+		case "engine":
+			if !contains(filter.Values, aws.ToString(g.Engine)) {
+				return false, nil
+			}
+		default:
+			return false, invalidParameterValue("Unrecognized filter name: %s", name)
+		}
+	}
+	return true, nil
+}
+
+// RemoveFromGlobalCluster detaches the DB cluster, which becomes a
+// standalone cluster. The writer can only be removed last.
+func (f *RDS) RemoveFromGlobalCluster(_ context.Context, params *rds.RemoveFromGlobalClusterInput, _ ...func(*rds.Options)) (*rds.RemoveFromGlobalClusterOutput, error) {
+	id := aws.ToString(params.GlobalClusterIdentifier)
+	clusterArn := aws.ToString(params.DbClusterIdentifier)
+	if clusterArn == "" {
+		return nil, missingParameter("DbClusterIdentifier")
+	}
+	f.mu.Lock()
+	globals := f.globals
+	f.mu.Unlock()
+
+	globals.mu.Lock()
+	g, ok := globals.clusters[id]
+	if !ok {
+		globals.mu.Unlock()
+		return nil, globalClusterNotFound(id)
+	}
+	n, ok := g.member(clusterArn)
+	if !ok {
+		globals.mu.Unlock()
+		return nil, invalidParameterValue("DBCluster %s is not a member of GlobalCluster %s.", clusterArn, id)
+	}
+	if g.GlobalClusterMembers[n].IsWriter && len(g.GlobalClusterMembers) > 1 {
+		globals.mu.Unlock()
+		return nil, invalidGlobalClusterState("The writer of GlobalCluster %s cannot be removed while it has secondary clusters.", id)
+	}
+	g.GlobalClusterMembers = append(g.GlobalClusterMembers[:n:n], g.GlobalClusterMembers[n+1:]...)
+	out := g.copy()
+	globals.mu.Unlock()
+
+	f.detachCluster(clusterArn)
+	return &rds.RemoveFromGlobalClusterOutput{GlobalCluster: &out}, nil
+}
+
+// FailoverGlobalCluster is the managed planned failover of RDS: the target
+// secondary becomes the writer without data loss, so every member must be
+// available.
+func (f *RDS) FailoverGlobalCluster(_ context.Context, params *rds.FailoverGlobalClusterInput, _ ...func(*rds.Options)) (*rds.FailoverGlobalClusterOutput, error) {
+	id := aws.ToString(params.GlobalClusterIdentifier)
+	target := aws.ToString(params.TargetDbClusterIdentifier)
+	if target == "" {
+		return nil, missingParameter("TargetDbClusterIdentifier")
+	}
+	f.mu.Lock()
+	globals := f.globals
+	f.mu.Unlock()
+
+	globals.mu.Lock()
+	g, ok := globals.clusters[id]
+	if !ok {
+		globals.mu.Unlock()
+		return nil, globalClusterNotFound(id)
+	}
+	members := append([]types.GlobalClusterMember(nil), g.GlobalClusterMembers...)
+	globals.mu.Unlock()
+
+	for _, m := range members {
+		c, err := f.sourceCluster(aws.ToString(m.DBClusterArn))
+		if err != nil {
+			return nil, err
+		}
+		if status := aws.ToString(c.Status); status != StatusAvailable {
+			return nil, invalidClusterState(aws.ToString(c.DBClusterIdentifier), status)
+		}
+	}
+
+	globals.mu.Lock()
+	defer globals.mu.Unlock()
+	if status := aws.ToString(g.Status); status != StatusAvailable {
+		return nil, invalidGlobalClusterState("GlobalCluster %s is in %s state.", id, status)
+	}
+	n, ok := g.member(target)
+	if !ok {
+		return nil, invalidParameterValue("DBCluster %s is not a member of GlobalCluster %s.", target, id)
+	}
+	if g.GlobalClusterMembers[n].IsWriter {
+		return nil, invalidParameterValue("DBCluster %s is already the writer of GlobalCluster %s.", target, id)
+	}
+	state := &types.FailoverState{ToDbClusterArn: aws.String(target), Status: types.FailoverStatusPending}
+	for n := range g.GlobalClusterMembers {
+		m := &g.GlobalClusterMembers[n]
+		if m.IsWriter {
+			state.FromDbClusterArn = m.DBClusterArn
+		}
+		m.IsWriter = aws.ToString(m.DBClusterArn) == target
+	}
+	g.FailoverState = state
+	g.Status = aws.String(StatusFailingOver)
+	g.transitions = []string{StatusAvailable}
+	out := g.copy()
+	return &rds.FailoverGlobalClusterOutput{GlobalCluster: &out}, nil
+}
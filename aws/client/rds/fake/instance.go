@@ -0,0 +1,296 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+func instanceNotFound(id string) error {
+	return &types.DBInstanceNotFoundFault{Message: aws.String(fmt.Sprintf("DBInstance %s not found.", id))}
+}
+
+func invalidInstanceState(id, status string) error {
+	return &types.InvalidDBInstanceStateFault{Message: aws.String(fmt.Sprintf("DBInstance %s is in %s state.", id, status))}
+}
+
+func (f *RDS) CreateDBInstance(_ context.Context, params *rds.CreateDBInstanceInput, _ ...func(*rds.Options)) (*rds.CreateDBInstanceOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := aws.ToString(params.DBInstanceIdentifier)
+	if id == "" {
+		return nil, missingParameter("DBInstanceIdentifier")
+	}
+	if _, ok := f.instances[id]; ok {
+		return nil, &types.DBInstanceAlreadyExistsFault{Message: aws.String(fmt.Sprintf("DBInstance %s already exists.", id))}
+	}
+	if aws.ToString(params.Engine) == "" {
+		return nil, missingParameter("Engine")
+	}
+	if aws.ToString(params.DBInstanceClass) == "" {
+		return nil, missingParameter("DBInstanceClass")
+	}
+
+	i := &dbInstance{DBInstance: types.DBInstance{
+		DBInstanceIdentifier: aws.String(id),
+		DBInstanceArn:        aws.String(f.arn("db", id)),
+		DbiResourceId:        aws.String(f.nextID("db")),
+		DBInstanceClass:      params.DBInstanceClass,
+		Engine:               params.Engine,
+		EngineVersion:        params.EngineVersion,
+		MasterUsername:       params.MasterUsername,
+		DBName:               params.DBName,
+		AllocatedStorage:     aws.ToInt32(params.AllocatedStorage),
+		Iops:                 params.Iops,
+		StorageType:          params.StorageType,
+		MultiAZ:              aws.ToBool(params.MultiAZ),
+		AvailabilityZone:     params.AvailabilityZone,
+		PubliclyAccessible:   aws.ToBool(params.PubliclyAccessible),
+		LicenseModel:         params.LicenseModel,
+		DBClusterIdentifier:  params.DBClusterIdentifier,
+		PromotionTier:        params.PromotionTier,
+		TagList:              params.Tags,
+		StorageEncrypted:     aws.ToBool(params.StorageEncrypted),
+		KmsKeyId:             params.KmsKeyId,
+		DBInstanceStatus:     aws.String(StatusCreating),
+		InstanceCreateTime:   now(),
+	}, transitions: []string{StatusAvailable}}
+
+	if cid := aws.ToString(params.DBClusterIdentifier); cid != "" {
+		c, ok := f.clusters[cid]
+		if !ok {
+			return nil, clusterNotFound(cid)
+		}
+		if err := checkServerlessInstance(c, aws.ToString(params.DBInstanceClass)); err != nil {
+			return nil, err
+		}
+		i.Engine = c.Engine
+		i.EngineVersion = c.EngineVersion
+		i.MasterUsername = c.MasterUsername
+		i.DBName = c.DatabaseName
+		f.addMember(c, i)
+	}
+
+	port := aws.ToInt32(params.Port)
+	if port == 0 {
+		port = defaultPort(aws.ToString(i.Engine))
+	}
+	i.DbInstancePort = port
+	i.Endpoint = &types.Endpoint{Address: aws.String(f.host(id, "")), Port: port}
+	for _, sg := range params.VpcSecurityGroupIds {
+		i.VpcSecurityGroups = append(i.VpcSecurityGroups, types.VpcSecurityGroupMembership{
+			VpcSecurityGroupId: aws.String(sg),
+			Status:             aws.String("active"),
+		})
+	}
+	if name := aws.ToString(params.DBSubnetGroupName); name != "" {
+		sg, ok := f.subnetGroups[name]
+		if !ok {
+			return nil, subnetGroupNotFound(name)
+		}
+		i.DBSubnetGroup = sg
+	}
+	group := aws.ToString(params.DBParameterGroupName)
+	if group == "" {
+		group = defaultParameterGroupPrefix + aws.ToString(i.Engine)
+	}
+	if err := f.attachParameterGroup(group, aws.ToString(i.Engine), false); err != nil {
+		return nil, err
+	}
+	i.DBParameterGroups = []types.DBParameterGroupStatus{{
+		DBParameterGroupName: aws.String(group),
+		ParameterApplyStatus: aws.String(applyStatusInSync),
+	}}
+
+	f.instances[id] = i
+	out := i.DBInstance
+	return &rds.CreateDBInstanceOutput{DBInstance: &out}, nil
+}
+
+func (f *RDS) DeleteDBInstance(_ context.Context, params *rds.DeleteDBInstanceInput, _ ...func(*rds.Options)) (*rds.DeleteDBInstanceOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := aws.ToString(params.DBInstanceIdentifier)
+	i, ok := f.instances[id]
+	if !ok {
+		return nil, instanceNotFound(id)
+	}
+	if aws.ToString(i.DBInstanceStatus) == StatusDeleting {
+		return nil, invalidInstanceState(id, StatusDeleting)
+	}
+	if aws.ToString(i.DBClusterIdentifier) == "" && !params.SkipFinalSnapshot && aws.ToString(params.FinalDBSnapshotIdentifier) == "" {
+		return nil, invalidParameterCombination("FinalDBSnapshotIdentifier is required unless SkipFinalSnapshot is specified.")
+	}
+	if sid := aws.ToString(params.FinalDBSnapshotIdentifier); sid != "" && !params.SkipFinalSnapshot {
+		if _, ok := f.snapshots[sid]; ok {
+			return nil, snapshotAlreadyExists(sid)
+		}
+		f.snapshots[sid] = f.newSnapshot(i, sid, nil)
+	}
+
+	i.DBInstanceStatus = aws.String(StatusDeleting)
+	i.transitions = []string{statusDeleted}
+	out := i.DBInstance
+	return &rds.DeleteDBInstanceOutput{DBInstance: &out}, nil
+}
+
+func (f *RDS) RebootDBInstance(_ context.Context, params *rds.RebootDBInstanceInput, _ ...func(*rds.Options)) (*rds.RebootDBInstanceOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := aws.ToString(params.DBInstanceIdentifier)
+	i, ok := f.instances[id]
+	if !ok {
+		return nil, instanceNotFound(id)
+	}
+	if status := aws.ToString(i.DBInstanceStatus); status != StatusAvailable {
+		return nil, invalidInstanceState(id, status)
+	}
+	if aws.ToBool(params.ForceFailover) && !i.MultiAZ {
+		return nil, invalidParameterCombination("ForceFailover cannot be specified since the instance is not configured for either MultiAZ or High Availability")
+	}
+
+	i.DBInstanceStatus = aws.String(StatusRebooting)
+	i.transitions = []string{StatusAvailable}
+	f.parametersInSync(i)
+	out := i.DBInstance
+	return &rds.RebootDBInstanceOutput{DBInstance: &out}, nil
+}
+
+func (f *RDS) DescribeDBInstances(_ context.Context, params *rds.DescribeDBInstancesInput, _ ...func(*rds.Options)) (*rds.DescribeDBInstancesOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.describe()
+
+	out := &rds.DescribeDBInstancesOutput{}
+	if id := aws.ToString(params.DBInstanceIdentifier); id != "" {
+		i, ok := f.instances[id]
+		if !ok {
+			return nil, instanceNotFound(id)
+		}
+		out.DBInstances = append(out.DBInstances, i.DBInstance)
+		return out, nil
+	}
+
+	ids := []string{}
+	for _, id := range sortedKeys(f.instances) {
+		match, err := matchInstance(f.instances[id], params.Filters)
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			ids = append(ids, id)
+		}
+	}
+	page, marker, err := paginate(ids, params.Marker, params.MaxRecords)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range page {
+		out.DBInstances = append(out.DBInstances, f.instances[id].DBInstance)
+	}
+	out.Marker = marker
+	return out, nil
+}
+
+// matchInstance reports whether the instance matches all filters.
+func matchInstance(i *dbInstance, filters []types.Filter) (bool, error) {
+	for _, filter := range filters {
+		var value string
+		switch name := aws.ToString(filter.Name); name {
+		case "db-cluster-id":
+			value = aws.ToString(i.DBClusterIdentifier)
+		case "db-instance-id":
+			value = aws.ToString(i.DBInstanceIdentifier)
+		case "engine":
+			value = aws.ToString(i.Engine)
+		default:
+			return false, invalidParameterValue("Unrecognized filter name: %s", name)
+		}
+		if !contains(filter.Values, value) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (f *RDS) RestoreDBInstanceToPointInTime(_ context.Context, params *rds.RestoreDBInstanceToPointInTimeInput, _ ...func(*rds.Options)) (*rds.RestoreDBInstanceToPointInTimeOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var source *dbInstance
+	if sid := aws.ToString(params.SourceDBInstanceIdentifier); sid != "" {
+		source = f.instances[sid]
+	} else if rid := aws.ToString(params.SourceDbiResourceId); rid != "" {
+		for _, i := range f.instances {
+			if aws.ToString(i.DbiResourceId) == rid {
+				source = i
+			}
+		}
+	} else {
+		return nil, missingParameter("SourceDBInstanceIdentifier")
+	}
+	if source == nil {
+		return nil, instanceNotFound(aws.ToString(params.SourceDBInstanceIdentifier) + aws.ToString(params.SourceDbiResourceId))
+	}
+	if params.RestoreTime != nil && params.UseLatestRestorableTime {
+		return nil, invalidParameterCombination("RestoreTime and UseLatestRestorableTime cannot be used together.")
+	}
+
+	id := aws.ToString(params.TargetDBInstanceIdentifier)
+	if id == "" {
+		return nil, missingParameter("TargetDBInstanceIdentifier")
+	}
+	if _, ok := f.instances[id]; ok {
+		return nil, &types.DBInstanceAlreadyExistsFault{Message: aws.String(fmt.Sprintf("DBInstance %s already exists.", id))}
+	}
+
+	i := &dbInstance{DBInstance: source.DBInstance, transitions: []string{StatusAvailable}}
+	i.DBInstanceIdentifier = aws.String(id)
+	i.DBInstanceArn = aws.String(f.arn("db", id))
+	i.DbiResourceId = aws.String(f.nextID("db"))
+	i.DBInstanceStatus = aws.String(StatusCreating)
+	i.InstanceCreateTime = now()
+	i.Endpoint = &types.Endpoint{Address: aws.String(f.host(id, "")), Port: source.DbInstancePort}
+	i.ReadReplicaDBInstanceIdentifiers = nil
+	i.ReadReplicaSourceDBInstanceIdentifier = nil
+	i.TagList = params.Tags
+	if params.DBInstanceClass != nil {
+		i.DBInstanceClass = params.DBInstanceClass
+	}
+	if params.DBName != nil {
+		i.DBName = params.DBName
+	}
+	if params.Iops != nil {
+		i.Iops = params.Iops
+	}
+	if params.MultiAZ != nil {
+		i.MultiAZ = aws.ToBool(params.MultiAZ)
+	}
+	if params.AvailabilityZone != nil {
+		i.AvailabilityZone = params.AvailabilityZone
+	}
+
+	f.instances[id] = i
+	out := i.DBInstance
+	return &rds.RestoreDBInstanceToPointInTimeOutput{DBInstance: &out}, nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+// maxPortionLines is the number of lines of a portion without NumberOfLines.
+const maxPortionLines = 10000
+
+type dbLogFile struct {
+	lines       []string
+	lastWritten time.Time
+}
+
+func (l *dbLogFile) size() int64 {
+	var size int64
+	for _, line := range l.lines {
+		size += int64(len(line)) + 1
+	}
+	return size
+}
+
+// logFiles returns the log files of the instance, starting with the empty
+// logs of its engine.
+func (i *dbInstance) logFiles() map[string]*dbLogFile {
+	if i.logs == nil {
+		i.logs = map[string]*dbLogFile{}
+		for _, name := range defaultLogFiles(aws.ToString(i.Engine)) {
+			i.logs[name] = &dbLogFile{lastWritten: aws.ToTime(i.InstanceCreateTime)}
+		}
+	}
+	return i.logs
+}
+
+func defaultLogFiles(engine string) []string {
+	switch {
+	case strings.Contains(engine, "postgres"):
+		return []string{"error/postgresql.log"}
+	case strings.Contains(engine, "mysql"), engine == "mariadb":
+		return []string{"error/mysql-error.log", "slowquery/mysql-slowquery.log"}
+	}
+	return []string{"error/error.log"}
+}
+
+func logFileNotFound(name string) error {
+	return &types.DBLogFileNotFoundFault{Message: aws.String(fmt.Sprintf("DBLog File: %s, is not found on the DB instance", name))}
+}
+
+// WriteLog appends lines to a log file of an instance, creating the file if
+// needed. Unknown instances are ignored.
+func (f *RDS) WriteLog(id, name string, lines ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i, ok := f.instances[id]
+	if !ok {
+		return
+	}
+	logs := i.logFiles()
+	l, ok := logs[name]
+	if !ok {
+		l = &dbLogFile{}
+		logs[name] = l
+	}
+	l.lines = append(l.lines, lines...)
+	l.lastWritten = *now()
+}
+
+func (f *RDS) DescribeDBLogFiles(_ context.Context, params *rds.DescribeDBLogFilesInput, _ ...func(*rds.Options)) (*rds.DescribeDBLogFilesOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.describe()
+
+	id := aws.ToString(params.DBInstanceIdentifier)
+	if id == "" {
+		return nil, missingParameter("DBInstanceIdentifier")
+	}
+	i, ok := f.instances[id]
+	if !ok {
+		return nil, instanceNotFound(id)
+	}
+
+	logs := i.logFiles()
+	names := []string{}
+	for _, name := range sortedKeys(logs) {
+		l := logs[name]
+		if !strings.Contains(name, aws.ToString(params.FilenameContains)) {
+			continue
+		}
+		if l.lastWritten.UnixMilli() < params.FileLastWritten || l.size() < params.FileSize {
+			continue
+		}
+		names = append(names, name)
+	}
+	page, marker, err := paginate(names, params.Marker, params.MaxRecords)
+	if err != nil {
+		return nil, err
+	}
+	out := &rds.DescribeDBLogFilesOutput{Marker: marker}
+	for _, name := range page {
+		out.DescribeDBLogFiles = append(out.DescribeDBLogFiles, types.DescribeDBLogFilesDetails{
+			LogFileName: aws.String(name),
+			LastWritten: logs[name].lastWritten.UnixMilli(),
+			Size:        logs[name].size(),
+		})
+	}
+	return out, nil
+}
+
+// DownloadDBLogFilePortion returns the NumberOfLines last lines of the file
+// without Marker, and the lines after the marker otherwise, starting from the
+// beginning with marker "0". Markers are line numbers.
+func (f *RDS) DownloadDBLogFilePortion(_ context.Context, params *rds.DownloadDBLogFilePortionInput, _ ...func(*rds.Options)) (*rds.DownloadDBLogFilePortionOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := aws.ToString(params.DBInstanceIdentifier)
+	if id == "" {
+		return nil, missingParameter("DBInstanceIdentifier")
+	}
+	name := aws.ToString(params.LogFileName)
+	if name == "" {
+		return nil, missingParameter("LogFileName")
+	}
+	i, ok := f.instances[id]
+	if !ok {
+		return nil, instanceNotFound(id)
+	}
+	l, ok := i.logFiles()[name]
+	if !ok {
+		return nil, logFileNotFound(name)
+	}
+	if params.NumberOfLines < 0 {
+		return nil, invalidParameterValue("Invalid value %d for NumberOfLines", params.NumberOfLines)
+	}
+	size := int(params.NumberOfLines)
+	if size == 0 {
+		size = maxPortionLines
+	}
+
+	start := len(l.lines) - size
+	if start < 0 {
+		start = 0
+	}
+	if m := aws.ToString(params.Marker); m != "" {
+		n, err := strconv.Atoi(m)
+		if err != nil || n < 0 || n > len(l.lines) {
+			return nil, invalidParameterValue("Invalid marker: %s", m)
+		}
+		start = n
+	}
+	end := start + size
+	if end > len(l.lines) {
+		end = len(l.lines)
+	}
+
+	var data strings.Builder
+	for _, line := range l.lines[start:end] {
+		data.WriteString(line)
+		data.WriteString("\n")
+	}
+	return &rds.DownloadDBLogFilePortionOutput{
+		LogFileData:           aws.String(data.String()),
+		Marker:                aws.String(strconv.Itoa(end)),
+		AdditionalDataPending: end < len(l.lines),
+	}, nil
+}
@@ -0,0 +1,289 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+// majorVersion returns the part of an engine version that changes on a
+// major upgrade, e.g. 8.0 for MySQL 8.0.28 and 14 for PostgreSQL 14.5.
+func majorVersion(engine, version string) string {
+	parts := strings.Split(version, ".")
+	n := 1
+	if strings.Contains(engine, "mysql") || strings.Contains(engine, "mariadb") {
+		n = 2
+	}
+	if len(parts) < n {
+		return version
+	}
+	return strings.Join(parts[:n], ".")
+}
+
+func checkEngineVersion(engine, current, next string, allowMajor bool) error {
+	if next == "" || allowMajor || majorVersion(engine, current) == majorVersion(engine, next) {
+		return nil
+	}
+	return invalidParameterCombination("The AllowMajorVersionUpgrade flag must be present when upgrading to a new major version.")
+}
+
+func (f *RDS) ModifyDBInstance(_ context.Context, params *rds.ModifyDBInstanceInput, _ ...func(*rds.Options)) (*rds.ModifyDBInstanceOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := aws.ToString(params.DBInstanceIdentifier)
+	i, ok := f.instances[id]
+	if !ok {
+		return nil, instanceNotFound(id)
+	}
+	if status := aws.ToString(i.DBInstanceStatus); status != StatusAvailable {
+		return nil, invalidInstanceState(id, status)
+	}
+	if params.AllocatedStorage != nil && *params.AllocatedStorage < i.AllocatedStorage {
+		return nil, invalidParameterCombination("Invalid storage size for engine name %s: allocated storage cannot be decreased.", aws.ToString(i.Engine))
+	}
+	if params.MaxAllocatedStorage != nil {
+		allocated := i.AllocatedStorage
+		if params.AllocatedStorage != nil {
+			allocated = *params.AllocatedStorage
+		}
+		if *params.MaxAllocatedStorage < allocated {
+			return nil, invalidParameterCombination("Max storage size must be greater than storage size.")
+		}
+	}
+	if err := checkEngineVersion(aws.ToString(i.Engine), aws.ToString(i.EngineVersion), aws.ToString(params.EngineVersion), params.AllowMajorVersionUpgrade); err != nil {
+		return nil, err
+	}
+	if params.MultiAZ != nil && aws.ToString(i.DBClusterIdentifier) != "" {
+		return nil, invalidParameterCombination("MultiAZ cannot be modified for an instance of a DB cluster.")
+	}
+	if name := aws.ToString(params.DBParameterGroupName); name != "" {
+		if err := f.attachParameterGroup(name, aws.ToString(i.Engine), false); err != nil {
+			return nil, err
+		}
+	}
+
+	// Changes without downtime are always applied right away.
+	if params.DeletionProtection != nil {
+		i.DeletionProtection = aws.ToBool(params.DeletionProtection)
+	}
+	if params.MaxAllocatedStorage != nil {
+		i.MaxAllocatedStorage = params.MaxAllocatedStorage
+	}
+	if params.BackupRetentionPeriod != nil {
+		i.BackupRetentionPeriod = aws.ToInt32(params.BackupRetentionPeriod)
+	}
+	if name := aws.ToString(params.DBParameterGroupName); name != "" {
+		i.DBParameterGroups = []types.DBParameterGroupStatus{{
+			DBParameterGroupName: aws.String(name),
+			ParameterApplyStatus: aws.String(applyStatusPendingReboot),
+		}}
+	}
+
+	pending := &types.PendingModifiedValues{
+		DBInstanceClass:  params.DBInstanceClass,
+		AllocatedStorage: params.AllocatedStorage,
+		Iops:             params.Iops,
+		StorageType:      params.StorageType,
+		EngineVersion:    params.EngineVersion,
+		MultiAZ:          params.MultiAZ,
+	}
+	if pending.DBInstanceClass != nil || pending.AllocatedStorage != nil || pending.Iops != nil ||
+		pending.StorageType != nil || pending.EngineVersion != nil || pending.MultiAZ != nil {
+		if params.ApplyImmediately {
+			applyInstanceModifications(i, pending)
+			i.DBInstanceStatus = aws.String(StatusModifying)
+			i.transitions = []string{StatusAvailable}
+		} else {
+			i.PendingModifiedValues = mergeInstanceModifications(i.PendingModifiedValues, pending)
+		}
+	}
+
+	out := i.DBInstance
+	return &rds.ModifyDBInstanceOutput{DBInstance: &out}, nil
+}
+
+func mergeInstanceModifications(current, next *types.PendingModifiedValues) *types.PendingModifiedValues {
+	if current == nil {
+		return next
+	}
+	merged := *current
+	if next.DBInstanceClass != nil {
+		merged.DBInstanceClass = next.DBInstanceClass
+	}
+	if next.AllocatedStorage != nil {
+		merged.AllocatedStorage = next.AllocatedStorage
+	}
+	if next.Iops != nil {
+		merged.Iops = next.Iops
+	}
+	if next.StorageType != nil {
+		merged.StorageType = next.StorageType
+	}
+	if next.EngineVersion != nil {
+		merged.EngineVersion = next.EngineVersion
+	}
+	if next.MultiAZ != nil {
+		merged.MultiAZ = next.MultiAZ
+	}
+	return &merged
+}
+
+func applyInstanceModifications(i *dbInstance, pending *types.PendingModifiedValues) {
+	if pending.DBInstanceClass != nil {
+		i.DBInstanceClass = pending.DBInstanceClass
+	}
+	if pending.AllocatedStorage != nil {
+		i.AllocatedStorage = aws.ToInt32(pending.AllocatedStorage)
+	}
+	if pending.Iops != nil {
+		i.Iops = pending.Iops
+	}
+	if pending.StorageType != nil {
+		i.StorageType = pending.StorageType
+	}
+	if pending.EngineVersion != nil {
+		i.EngineVersion = pending.EngineVersion
+	}
+	if pending.MultiAZ != nil {
+		i.MultiAZ = aws.ToBool(pending.MultiAZ)
+	}
+}
+
+func (f *RDS) ModifyDBCluster(_ context.Context, params *rds.ModifyDBClusterInput, _ ...func(*rds.Options)) (*rds.ModifyDBClusterOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := aws.ToString(params.DBClusterIdentifier)
+	c, ok := f.clusters[id]
+	if !ok {
+		return nil, clusterNotFound(id)
+	}
+	if status := aws.ToString(c.Status); status != StatusAvailable {
+		return nil, invalidClusterState(id, status)
+	}
+	if params.AllocatedStorage != nil && aws.ToInt32(params.AllocatedStorage) < aws.ToInt32(c.AllocatedStorage) {
+		return nil, invalidParameterCombination("Invalid storage size for engine name %s: allocated storage cannot be decreased.", aws.ToString(c.Engine))
+	}
+	if err := checkEngineVersion(aws.ToString(c.Engine), aws.ToString(c.EngineVersion), aws.ToString(params.EngineVersion), params.AllowMajorVersionUpgrade); err != nil {
+		return nil, err
+	}
+	if name := aws.ToString(params.DBClusterParameterGroupName); name != "" {
+		if err := f.attachParameterGroup(name, aws.ToString(c.Engine), true); err != nil {
+			return nil, err
+		}
+	}
+	// NOTE: Scaling changes are applied immediately.
+	if err := setScaling(c, params.ScalingConfiguration, params.ServerlessV2ScalingConfiguration); err != nil {
+		return nil, err
+	}
+
+	if params.DeletionProtection != nil {
+		c.DeletionProtection = params.DeletionProtection
+	}
+	if params.BackupRetentionPeriod != nil {
+		c.BackupRetentionPeriod = params.BackupRetentionPeriod
+	}
+	if name := aws.ToString(params.DBClusterParameterGroupName); name != "" {
+		c.DBClusterParameterGroup = aws.String(name)
+		for n := range c.DBClusterMembers {
+			c.DBClusterMembers[n].DBClusterParameterGroupStatus = aws.String(applyStatusPendingReboot)
+		}
+	}
+	if params.DBClusterInstanceClass != nil {
+		c.DBClusterInstanceClass = params.DBClusterInstanceClass
+	}
+	if params.StorageType != nil {
+		c.StorageType = params.StorageType
+	}
+
+	pending := &types.ClusterPendingModifiedValues{
+		AllocatedStorage: params.AllocatedStorage,
+		Iops:             params.Iops,
+		EngineVersion:    params.EngineVersion,
+	}
+	if pending.AllocatedStorage != nil || pending.Iops != nil || pending.EngineVersion != nil {
+		if params.ApplyImmediately {
+			applyClusterModifications(c, pending)
+			c.Status = aws.String(StatusModifying)
+			c.transitions = []string{StatusAvailable}
+		} else {
+			c.PendingModifiedValues = mergeClusterModifications(c.PendingModifiedValues, pending)
+		}
+	}
+
+	out := c.copy()
+	return &rds.ModifyDBClusterOutput{DBCluster: &out}, nil
+}
+
+func mergeClusterModifications(current, next *types.ClusterPendingModifiedValues) *types.ClusterPendingModifiedValues {
+	if current == nil {
+		return next
+	}
+	merged := *current
+	if next.AllocatedStorage != nil {
+		merged.AllocatedStorage = next.AllocatedStorage
+	}
+	if next.Iops != nil {
+		merged.Iops = next.Iops
+	}
+	if next.EngineVersion != nil {
+		merged.EngineVersion = next.EngineVersion
+	}
+	return &merged
+}
+
+func applyClusterModifications(c *dbCluster, pending *types.ClusterPendingModifiedValues) {
+	if pending.AllocatedStorage != nil {
+		c.AllocatedStorage = pending.AllocatedStorage
+	}
+	if pending.Iops != nil {
+		c.Iops = pending.Iops
+	}
+	if pending.EngineVersion != nil {
+		c.EngineVersion = pending.EngineVersion
+	}
+}
+
+// ApplyPendingModifications applies the modifications deferred to the
+// maintenance window, as if the window had just opened.
+func (f *RDS) ApplyPendingModifications() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, i := range f.instances {
+		if i.PendingModifiedValues == nil {
+			continue
+		}
+		applyInstanceModifications(i, i.PendingModifiedValues)
+		i.PendingModifiedValues = nil
+		i.DBInstanceStatus = aws.String(StatusModifying)
+		i.transitions = []string{StatusAvailable}
+	}
+	for _, c := range f.clusters {
+		if c.PendingModifiedValues == nil {
+			continue
+		}
+		applyClusterModifications(c, c.PendingModifiedValues)
+		c.PendingModifiedValues = nil
+		c.Status = aws.String(StatusModifying)
+		c.transitions = []string{StatusAvailable}
+	}
+}
@@ -0,0 +1,581 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+const (
+	defaultParameterGroupPrefix = "default."
+	applyStatusInSync           = "in-sync"
+	applyStatusPendingReboot    = "pending-reboot"
+	maxModifyParameters         = 20
+)
+
+// dbParameterGroup is a DB parameter group, or a DB cluster parameter group
+// when cluster is set.
+type dbParameterGroup struct {
+	types.DBParameterGroup
+	cluster bool
+	// values are the parameters set on the group, the others have the
+	// value of the engine.
+	values map[string]string
+}
+
+// parameterSpec is a parameter known by the fake. The fake only knows a
+// handful of parameters of each engine.
+type parameterSpec struct {
+	name      string
+	dataType  string
+	allowed   string
+	applyType string
+	value     string
+}
+
+var (
+	mysqlParameters = []parameterSpec{
+		{name: "innodb_buffer_pool_size", dataType: "integer", allowed: "5242880-18446744073709551615", applyType: "static", value: "{DBInstanceClassMemory*3/4}"},
+		{name: "long_query_time", dataType: "float", allowed: "0-31536000", applyType: "dynamic", value: "10"},
+		{name: "max_allowed_packet", dataType: "integer", allowed: "1024-1073741824", applyType: "dynamic", value: "67108864"},
+		{name: "max_connections", dataType: "integer", allowed: "1-100000", applyType: "dynamic", value: "{DBInstanceClassMemory/12582880}"},
+		{name: "slow_query_log", dataType: "boolean", allowed: "0,1", applyType: "dynamic", value: "0"},
+	}
+	mysqlClusterParameters = []parameterSpec{
+		{name: "binlog_format", dataType: "string", allowed: "ROW,STATEMENT,MIXED,OFF", applyType: "static", value: "OFF"},
+		{name: "character_set_server", dataType: "string", allowed: "latin1,utf8,utf8mb4", applyType: "dynamic"},
+		{name: "server_audit_logging", dataType: "boolean", allowed: "0,1", applyType: "dynamic", value: "0"},
+		{name: "time_zone", dataType: "string", allowed: "UTC,Asia/Shanghai,Europe/London,US/Pacific", applyType: "dynamic"},
+	}
+	postgresParameters = []parameterSpec{
+		{name: "log_min_duration_statement", dataType: "integer", allowed: "-1-2147483647", applyType: "dynamic", value: "-1"},
+		{name: "max_connections", dataType: "integer", allowed: "6-8388607", applyType: "static", value: "{DBInstanceClassMemory/9531392}"},
+		{name: "shared_buffers", dataType: "integer", allowed: "16-1073741823", applyType: "static", value: "{DBInstanceClassMemory/32768}"},
+		{name: "work_mem", dataType: "integer", allowed: "64-2147483647", applyType: "dynamic", value: "4096"},
+	}
+	postgresClusterParameters = []parameterSpec{
+		{name: "log_statement", dataType: "string", allowed: "none,ddl,mod,all", applyType: "dynamic", value: "none"},
+		{name: "shared_preload_libraries", dataType: "list", allowed: "auto_explain,pg_stat_statements", applyType: "static", value: "pg_stat_statements"},
+		{name: "timezone", dataType: "string", applyType: "dynamic", value: "UTC"},
+	}
+)
+
+func (g *dbParameterGroup) specs() []parameterSpec {
+	postgres := strings.Contains(aws.ToString(g.DBParameterGroupFamily), "postgres")
+	switch {
+	case postgres && g.cluster:
+		return postgresClusterParameters
+	case postgres:
+		return postgresParameters
+	case g.cluster:
+		return mysqlClusterParameters
+	default:
+		return mysqlParameters
+	}
+}
+
+func (g *dbParameterGroup) spec(name string) (parameterSpec, bool) {
+	for _, s := range g.specs() {
+		if s.name == name {
+			return s, true
+		}
+	}
+	return parameterSpec{}, false
+}
+
+func (g *dbParameterGroup) isDefault() bool {
+	return strings.HasPrefix(aws.ToString(g.DBParameterGroupName), defaultParameterGroupPrefix)
+}
+
+// validate checks the value against the data type and allowed values of the
+// parameter.
+func (s parameterSpec) validate(value string) error {
+	invalid := invalidParameterValue("Invalid parameter value: %s for: %s allowed values are: %s", value, s.name, s.allowed)
+	switch s.dataType {
+	case "integer":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return invalid
+		}
+		// The minimum may be negative, e.g. -1-2147483647.
+		sep := strings.Index(s.allowed[1:], "-") + 1
+		min, _ := strconv.ParseInt(s.allowed[:sep], 10, 64)
+		max, err := strconv.ParseInt(s.allowed[sep+1:], 10, 64)
+		if n < min || (err == nil && n > max) {
+			return invalid
+		}
+	case "float":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return invalid
+		}
+	case "list":
+		for _, v := range strings.Split(value, ",") {
+			if !contains(strings.Split(s.allowed, ","), v) {
+				return invalid
+			}
+		}
+	default:
+		if s.allowed != "" && !contains(strings.Split(s.allowed, ","), value) {
+			return invalid
+		}
+	}
+	return nil
+}
+
+func parameterGroupNotFound(name string, cluster bool) error {
+	if cluster {
+		return &types.DBClusterParameterGroupNotFoundFault{Message: aws.String(fmt.Sprintf("DBClusterParameterGroup %s not found.", name))}
+	}
+	return &types.DBParameterGroupNotFoundFault{Message: aws.String(fmt.Sprintf("DBParameterGroup %s not found.", name))}
+}
+
+func invalidParameterGroupState(format string, args ...interface{}) error {
+	return &types.InvalidDBParameterGroupStateFault{Message: aws.String(fmt.Sprintf(format, args...))}
+}
+
+func (f *RDS) parameterGroups(cluster bool) map[string]*dbParameterGroup {
+	if cluster {
+		return f.clusterParameterGroups
+	}
+	return f.instanceParameterGroups
+}
+
+func (f *RDS) newParameterGroup(name, family, description string, cluster bool) *dbParameterGroup {
+	resource := "pg"
+	if cluster {
+		resource = "cluster-pg"
+	}
+	return &dbParameterGroup{DBParameterGroup: types.DBParameterGroup{
+		DBParameterGroupName:   aws.String(name),
+		DBParameterGroupArn:    aws.String(f.arn(resource, name)),
+		DBParameterGroupFamily: aws.String(family),
+		Description:            aws.String(description),
+	}, cluster: cluster, values: map[string]string{}}
+}
+
+// parameterGroup returns the group, the default groups of every family
+// exist on first use like on RDS. It is called with f.mu held.
+func (f *RDS) parameterGroup(name string, cluster bool) (*dbParameterGroup, error) {
+	groups := f.parameterGroups(cluster)
+	if g, ok := groups[name]; ok {
+		return g, nil
+	}
+	if !strings.HasPrefix(name, defaultParameterGroupPrefix) {
+		return nil, parameterGroupNotFound(name, cluster)
+	}
+	family := strings.TrimPrefix(name, defaultParameterGroupPrefix)
+	g := f.newParameterGroup(name, family, "Default parameter group for "+family, cluster)
+	groups[name] = g
+	return g, nil
+}
+
+// attachParameterGroup checks that the group exists and matches the engine
+// of the database. It is called with f.mu held.
+func (f *RDS) attachParameterGroup(name, engine string, cluster bool) error {
+	g, err := f.parameterGroup(name, cluster)
+	if err != nil {
+		return err
+	}
+	if family := aws.ToString(g.DBParameterGroupFamily); !strings.HasPrefix(family, engine) {
+		return invalidParameterCombination("The parameter group %s with DBParameterGroupFamily %s cannot be used for this instance. Please use a Parameter Group with DBParameterGroupFamily %s", name, family, engine)
+	}
+	return nil
+}
+
+// parametersPendingReboot marks the databases using the group as waiting
+// for a reboot. It is called with f.mu held.
+func (f *RDS) parametersPendingReboot(g *dbParameterGroup) {
+	name := aws.ToString(g.DBParameterGroupName)
+	if g.cluster {
+		for _, c := range f.clusters {
+			if aws.ToString(c.DBClusterParameterGroup) != name {
+				continue
+			}
+			for n := range c.DBClusterMembers {
+				c.DBClusterMembers[n].DBClusterParameterGroupStatus = aws.String(applyStatusPendingReboot)
+			}
+		}
+		return
+	}
+	for _, i := range f.instances {
+		for n, s := range i.DBParameterGroups {
+			if aws.ToString(s.DBParameterGroupName) == name {
+				i.DBParameterGroups[n].ParameterApplyStatus = aws.String(applyStatusPendingReboot)
+			}
+		}
+	}
+}
+
+// parametersInSync is called with f.mu held when the instance reboots.
+func (f *RDS) parametersInSync(i *dbInstance) {
+	for n := range i.DBParameterGroups {
+		i.DBParameterGroups[n].ParameterApplyStatus = aws.String(applyStatusInSync)
+	}
+	if c, ok := f.clusters[aws.ToString(i.DBClusterIdentifier)]; ok {
+		for n, m := range c.DBClusterMembers {
+			if aws.ToString(m.DBInstanceIdentifier) == aws.ToString(i.DBInstanceIdentifier) {
+				c.DBClusterMembers[n].DBClusterParameterGroupStatus = aws.String(applyStatusInSync)
+			}
+		}
+	}
+}
+
+// inUse reports whether a database uses the group. It is called with f.mu
+// held.
+func (f *RDS) inUse(g *dbParameterGroup) bool {
+	name := aws.ToString(g.DBParameterGroupName)
+	if g.cluster {
+		for _, c := range f.clusters {
+			if aws.ToString(c.DBClusterParameterGroup) == name {
+				return true
+			}
+		}
+		return false
+	}
+	for _, i := range f.instances {
+		for _, s := range i.DBParameterGroups {
+			if aws.ToString(s.DBParameterGroupName) == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (f *RDS) createParameterGroup(name, family, description *string, tags []types.Tag, cluster bool) (*dbParameterGroup, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if aws.ToString(name) == "" {
+		return nil, missingParameter("DBParameterGroupName")
+	}
+	if aws.ToString(family) == "" {
+		return nil, missingParameter("DBParameterGroupFamily")
+	}
+	if aws.ToString(description) == "" {
+		return nil, missingParameter("Description")
+	}
+	if strings.HasPrefix(aws.ToString(name), defaultParameterGroupPrefix) {
+		return nil, invalidParameterValue("The parameter DBParameterGroupName is not a valid identifier, it cannot start with %q.", defaultParameterGroupPrefix)
+	}
+	groups := f.parameterGroups(cluster)
+	if _, ok := groups[aws.ToString(name)]; ok {
+		return nil, &types.DBParameterGroupAlreadyExistsFault{Message: aws.String(fmt.Sprintf("Parameter group %s already exists", aws.ToString(name)))}
+	}
+	g := f.newParameterGroup(aws.ToString(name), aws.ToString(family), aws.ToString(description), cluster)
+	groups[aws.ToString(name)] = g
+	f.tags[aws.ToString(g.DBParameterGroupArn)] = tags
+	return g, nil
+}
+
+// checkApplyMethod checks the apply method of a modified or reset parameter.
+func (g *dbParameterGroup) checkApplyMethod(p types.Parameter) (parameterSpec, error) {
+	name := aws.ToString(p.ParameterName)
+	spec, ok := g.spec(name)
+	if !ok {
+		return spec, invalidParameterValue("Could not find parameter with name: %s", name)
+	}
+	switch p.ApplyMethod {
+	case types.ApplyMethodImmediate:
+		if spec.applyType == "static" {
+			return spec, invalidParameterCombination("cannot use immediate apply method for static parameter %s", name)
+		}
+	case types.ApplyMethodPendingReboot:
+	default:
+		return spec, invalidParameterValue("Invalid apply method %q for parameter %s", p.ApplyMethod, name)
+	}
+	return spec, nil
+}
+
+func (f *RDS) modifyParameterGroup(name *string, params []types.Parameter, cluster bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	g, err := f.parameterGroup(aws.ToString(name), cluster)
+	if err != nil {
+		return err
+	}
+	if g.isDefault() {
+		return invalidParameterGroupState("Default parameter groups cannot be modified.")
+	}
+	if len(params) == 0 {
+		return missingParameter("Parameters")
+	}
+	if len(params) > maxModifyParameters {
+		return invalidParameterValue("At most %d parameters can be modified at once.", maxModifyParameters)
+	}
+	for _, p := range params {
+		spec, err := g.checkApplyMethod(p)
+		if err != nil {
+			return err
+		}
+		if err := spec.validate(aws.ToString(p.ParameterValue)); err != nil {
+			return err
+		}
+	}
+
+	reboot := false
+	for _, p := range params {
+		g.values[aws.ToString(p.ParameterName)] = aws.ToString(p.ParameterValue)
+		reboot = reboot || p.ApplyMethod == types.ApplyMethodPendingReboot
+	}
+	if reboot {
+		f.parametersPendingReboot(g)
+	}
+	return nil
+}
+
+func (f *RDS) resetParameterGroup(name *string, params []types.Parameter, all, cluster bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	g, err := f.parameterGroup(aws.ToString(name), cluster)
+	if err != nil {
+		return err
+	}
+	if g.isDefault() {
+		return invalidParameterGroupState("Default parameter groups cannot be modified.")
+	}
+	if all == (len(params) > 0) {
+		return invalidParameterCombination("Specify either ResetAllParameters or Parameters.")
+	}
+
+	reboot := false
+	if all {
+		for n := range g.values {
+			spec, _ := g.spec(n)
+			reboot = reboot || spec.applyType == "static"
+		}
+		g.values = map[string]string{}
+	}
+	for _, p := range params {
+		if _, err := g.checkApplyMethod(p); err != nil {
+			return err
+		}
+	}
+	for _, p := range params {
+		delete(g.values, aws.ToString(p.ParameterName))
+		reboot = reboot || p.ApplyMethod == types.ApplyMethodPendingReboot
+	}
+	if reboot {
+		f.parametersPendingReboot(g)
+	}
+	return nil
+}
+
+func (f *RDS) deleteParameterGroup(name *string, cluster bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	g, err := f.parameterGroup(aws.ToString(name), cluster)
+	if err != nil {
+		return err
+	}
+	if g.isDefault() {
+		return invalidParameterGroupState("Default parameter groups cannot be deleted.")
+	}
+	if f.inUse(g) {
+		return invalidParameterGroupState("One or more database instances are still members of this parameter group %s, so the group cannot be deleted", aws.ToString(name))
+	}
+	delete(f.parameterGroups(cluster), aws.ToString(name))
+	delete(f.tags, aws.ToString(g.DBParameterGroupArn))
+	return nil
+}
+
+func (f *RDS) describeParameterGroups(name, marker *string, maxRecords *int32, cluster bool) ([]*dbParameterGroup, *string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if name := aws.ToString(name); name != "" {
+		g, err := f.parameterGroup(name, cluster)
+		if err != nil {
+			return nil, nil, err
+		}
+		return []*dbParameterGroup{g}, nil, nil
+	}
+	groups := f.parameterGroups(cluster)
+	page, next, err := paginate(sortedKeys(groups), marker, maxRecords)
+	if err != nil {
+		return nil, nil, err
+	}
+	out := []*dbParameterGroup{}
+	for _, n := range page {
+		out = append(out, groups[n])
+	}
+	return out, next, nil
+}
+
+func (f *RDS) describeParameters(name, source, marker *string, maxRecords *int32, cluster bool) ([]types.Parameter, *string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	g, err := f.parameterGroup(aws.ToString(name), cluster)
+	if err != nil {
+		return nil, nil, err
+	}
+	params := map[string]types.Parameter{}
+	for _, s := range g.specs() {
+		p := types.Parameter{
+			ParameterName: aws.String(s.name),
+			DataType:      aws.String(s.dataType),
+			ApplyType:     aws.String(s.applyType),
+			ApplyMethod:   types.ApplyMethodPendingReboot,
+			Source:        aws.String("engine-default"),
+			IsModifiable:  true,
+		}
+		if s.allowed != "" {
+			p.AllowedValues = aws.String(s.allowed)
+		}
+		if s.value != "" {
+			p.ParameterValue = aws.String(s.value)
+		}
+		if s.applyType == "dynamic" {
+			p.ApplyMethod = types.ApplyMethodImmediate
+		}
+		if v, ok := g.values[s.name]; ok {
+			p.ParameterValue = aws.String(v)
+			p.Source = aws.String("user")
+		}
+		if src := aws.ToString(source); src != "" && src != aws.ToString(p.Source) {
+			continue
+		}
+		params[s.name] = p
+	}
+
+	page, next, err := paginate(sortedKeys(params), marker, maxRecords)
+	if err != nil {
+		return nil, nil, err
+	}
+	out := []types.Parameter{}
+	for _, n := range page {
+		out = append(out, params[n])
+	}
+	return out, next, nil
+}
+
+func (f *RDS) CreateDBParameterGroup(_ context.Context, params *rds.CreateDBParameterGroupInput, _ ...func(*rds.Options)) (*rds.CreateDBParameterGroupOutput, error) {
+	g, err := f.createParameterGroup(params.DBParameterGroupName, params.DBParameterGroupFamily, params.Description, params.Tags, false)
+	if err != nil {
+		return nil, err
+	}
+	out := g.DBParameterGroup
+	return &rds.CreateDBParameterGroupOutput{DBParameterGroup: &out}, nil
+}
+
+func (f *RDS) ModifyDBParameterGroup(_ context.Context, params *rds.ModifyDBParameterGroupInput, _ ...func(*rds.Options)) (*rds.ModifyDBParameterGroupOutput, error) {
+	if err := f.modifyParameterGroup(params.DBParameterGroupName, params.Parameters, false); err != nil {
+		return nil, err
+	}
+	return &rds.ModifyDBParameterGroupOutput{DBParameterGroupName: params.DBParameterGroupName}, nil
+}
+
+func (f *RDS) ResetDBParameterGroup(_ context.Context, params *rds.ResetDBParameterGroupInput, _ ...func(*rds.Options)) (*rds.ResetDBParameterGroupOutput, error) {
+	if err := f.resetParameterGroup(params.DBParameterGroupName, params.Parameters, params.ResetAllParameters, false); err != nil {
+		return nil, err
+	}
+	return &rds.ResetDBParameterGroupOutput{DBParameterGroupName: params.DBParameterGroupName}, nil
+}
+
+func (f *RDS) DeleteDBParameterGroup(_ context.Context, params *rds.DeleteDBParameterGroupInput, _ ...func(*rds.Options)) (*rds.DeleteDBParameterGroupOutput, error) {
+	if err := f.deleteParameterGroup(params.DBParameterGroupName, false); err != nil {
+		return nil, err
+	}
+	return &rds.DeleteDBParameterGroupOutput{}, nil
+}
+
+func (f *RDS) DescribeDBParameterGroups(_ context.Context, params *rds.DescribeDBParameterGroupsInput, _ ...func(*rds.Options)) (*rds.DescribeDBParameterGroupsOutput, error) {
+	groups, marker, err := f.describeParameterGroups(params.DBParameterGroupName, params.Marker, params.MaxRecords, false)
+	if err != nil {
+		return nil, err
+	}
+	out := &rds.DescribeDBParameterGroupsOutput{Marker: marker}
+	for _, g := range groups {
+		out.DBParameterGroups = append(out.DBParameterGroups, g.DBParameterGroup)
+	}
+	return out, nil
+}
+
+func (f *RDS) DescribeDBParameters(_ context.Context, params *rds.DescribeDBParametersInput, _ ...func(*rds.Options)) (*rds.DescribeDBParametersOutput, error) {
+	parameters, marker, err := f.describeParameters(params.DBParameterGroupName, params.Source, params.Marker, params.MaxRecords, false)
+	if err != nil {
+		return nil, err
+	}
+	return &rds.DescribeDBParametersOutput{Parameters: parameters, Marker: marker}, nil
+}
+
+func (f *RDS) CreateDBClusterParameterGroup(_ context.Context, params *rds.CreateDBClusterParameterGroupInput, _ ...func(*rds.Options)) (*rds.CreateDBClusterParameterGroupOutput, error) {
+	g, err := f.createParameterGroup(params.DBClusterParameterGroupName, params.DBParameterGroupFamily, params.Description, params.Tags, true)
+	if err != nil {
+		return nil, err
+	}
+	return &rds.CreateDBClusterParameterGroupOutput{DBClusterParameterGroup: g.clusterParameterGroup()}, nil
+}
+
+func (g *dbParameterGroup) clusterParameterGroup() *types.DBClusterParameterGroup {
+	return &types.DBClusterParameterGroup{
+		DBClusterParameterGroupName: g.DBParameterGroupName,
+		DBClusterParameterGroupArn:  g.DBParameterGroupArn,
+		DBParameterGroupFamily:      g.DBParameterGroupFamily,
+		Description:                 g.Description,
+	}
+}
+
+func (f *RDS) ModifyDBClusterParameterGroup(_ context.Context, params *rds.ModifyDBClusterParameterGroupInput, _ ...func(*rds.Options)) (*rds.ModifyDBClusterParameterGroupOutput, error) {
+	if err := f.modifyParameterGroup(params.DBClusterParameterGroupName, params.Parameters, true); err != nil {
+		return nil, err
+	}
+	return &rds.ModifyDBClusterParameterGroupOutput{DBClusterParameterGroupName: params.DBClusterParameterGroupName}, nil
+}
+
+func (f *RDS) ResetDBClusterParameterGroup(_ context.Context, params *rds.ResetDBClusterParameterGroupInput, _ ...func(*rds.Options)) (*rds.ResetDBClusterParameterGroupOutput, error) {
+	if err := f.resetParameterGroup(params.DBClusterParameterGroupName, params.Parameters, params.ResetAllParameters, true); err != nil {
+		return nil, err
+	}
+	return &rds.ResetDBClusterParameterGroupOutput{DBClusterParameterGroupName: params.DBClusterParameterGroupName}, nil
+}
+
+func (f *RDS) DeleteDBClusterParameterGroup(_ context.Context, params *rds.DeleteDBClusterParameterGroupInput, _ ...func(*rds.Options)) (*rds.DeleteDBClusterParameterGroupOutput, error) {
+	if err := f.deleteParameterGroup(params.DBClusterParameterGroupName, true); err != nil {
+		return nil, err
+	}
+	return &rds.DeleteDBClusterParameterGroupOutput{}, nil
+}
+
+func (f *RDS) DescribeDBClusterParameterGroups(_ context.Context, params *rds.DescribeDBClusterParameterGroupsInput, _ ...func(*rds.Options)) (*rds.DescribeDBClusterParameterGroupsOutput, error) {
+	groups, marker, err := f.describeParameterGroups(params.DBClusterParameterGroupName, params.Marker, params.MaxRecords, true)
+	if err != nil {
+		return nil, err
+	}
+	out := &rds.DescribeDBClusterParameterGroupsOutput{Marker: marker}
+	for _, g := range groups {
+		out.DBClusterParameterGroups = append(out.DBClusterParameterGroups, *g.clusterParameterGroup())
+	}
+	return out, nil
+}
+
+func (f *RDS) DescribeDBClusterParameters(_ context.Context, params *rds.DescribeDBClusterParametersInput, _ ...func(*rds.Options)) (*rds.DescribeDBClusterParametersOutput, error) {
+	parameters, marker, err := f.describeParameters(params.DBClusterParameterGroupName, params.Source, params.Marker, params.MaxRecords, true)
+	if err != nil {
+		return nil, err
+	}
+	return &rds.DescribeDBClusterParametersOutput{Parameters: parameters, Marker: marker}, nil
+}
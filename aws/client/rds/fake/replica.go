@@ -0,0 +1,267 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+// sourceRegion returns the region and the identifier of source, which is
+// an identifier of this region or an ARN. It is called with f.mu held.
+func (f *RDS) sourceRegion(source string) (string, string, error) {
+	if !arn.IsARN(source) {
+		return f.region, source, nil
+	}
+	a, err := arn.Parse(source)
+	if err != nil {
+		return "", "", invalidParameterValue("Invalid source instance identifier: %s", source)
+	}
+	return a.Region, a.Resource[strings.LastIndex(a.Resource, ":")+1:], nil
+}
+
+// sourceInstance returns a copy of the source instance of a read replica,
+// which is looked up in the peers when it is an ARN of another region.
+func (f *RDS) sourceInstance(source string) (types.DBInstance, error) {
+	f.mu.Lock()
+	region, id, err := f.sourceRegion(source)
+	if err != nil {
+		f.mu.Unlock()
+		return types.DBInstance{}, err
+	}
+	if region == f.region {
+		defer f.mu.Unlock()
+		i, ok := f.instances[id]
+		if !ok {
+			return types.DBInstance{}, instanceNotFound(source)
+		}
+		return i.DBInstance, nil
+	}
+
+	peer, ok := f.peers[region]
+	f.mu.Unlock()
+	if !ok {
+		return types.DBInstance{}, instanceNotFound(source)
+	}
+	return peer.sourceInstance(id)
+}
+
+// linkReplica adds replica to the replicas of the source instance, or
+// removes it when link is false. Replicas of another region are added by
+// ARN.
+func (f *RDS) linkReplica(source, replica string, link bool) {
+	f.mu.Lock()
+	region, id, err := f.sourceRegion(source)
+	if err != nil {
+		f.mu.Unlock()
+		return
+	}
+	if region == f.region {
+		defer f.mu.Unlock()
+		if i, ok := f.instances[id]; ok {
+			i.ReadReplicaDBInstanceIdentifiers = without(i.ReadReplicaDBInstanceIdentifiers, replica)
+			if link {
+				i.ReadReplicaDBInstanceIdentifiers = append(i.ReadReplicaDBInstanceIdentifiers, replica)
+			}
+		}
+		return
+	}
+
+	peer, ok := f.peers[region]
+	replicaArn := f.arn("db", replica)
+	f.mu.Unlock()
+	if ok {
+		peer.linkReplica(id, replicaArn, link)
+	}
+}
+
+// removeReplicas is called with f.mu held when the instance is removed. It
+// is removed from its source, and its replicas are promoted like RDS does.
+// NOTE: Links to other regions are left in place since the peers cannot be
+// locked here.
+func (f *RDS) removeReplicas(i *dbInstance) {
+	if source, ok := f.instances[aws.ToString(i.ReadReplicaSourceDBInstanceIdentifier)]; ok {
+		source.ReadReplicaDBInstanceIdentifiers = without(source.ReadReplicaDBInstanceIdentifiers, aws.ToString(i.DBInstanceIdentifier))
+	}
+	for _, id := range i.ReadReplicaDBInstanceIdentifiers {
+		if r, ok := f.instances[id]; ok {
+			promote(r)
+		}
+	}
+}
+
+func without(values []string, value string) []string {
+	out := []string{}
+	for _, v := range values {
+		if v != value {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// promote turns the replica into a standalone instance, keeping its status.
+func promote(i *dbInstance) {
+	i.ReadReplicaSourceDBInstanceIdentifier = nil
+	i.StatusInfos = nil
+}
+
+func (f *RDS) CreateDBInstanceReadReplica(_ context.Context, params *rds.CreateDBInstanceReadReplicaInput, _ ...func(*rds.Options)) (*rds.CreateDBInstanceReadReplicaOutput, error) {
+	sid := aws.ToString(params.SourceDBInstanceIdentifier)
+	if sid == "" {
+		return nil, missingParameter("SourceDBInstanceIdentifier")
+	}
+	source, err := f.sourceInstance(sid)
+	if err != nil {
+		return nil, err
+	}
+	out, err := f.createReadReplica(source, params)
+	if err != nil {
+		return nil, err
+	}
+	f.linkReplica(sid, aws.ToString(out.DBInstanceIdentifier), true)
+	return &rds.CreateDBInstanceReadReplicaOutput{DBInstance: out}, nil
+}
+
+// createReadReplica adds the replica of source, linkReplica adds it to the
+// source afterwards.
+func (f *RDS) createReadReplica(source types.DBInstance, params *rds.CreateDBInstanceReadReplicaInput) (*types.DBInstance, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sid := aws.ToString(params.SourceDBInstanceIdentifier)
+	if status := aws.ToString(source.DBInstanceStatus); status != StatusAvailable {
+		return nil, invalidInstanceState(sid, status)
+	}
+	if aws.ToString(source.DBClusterIdentifier) != "" {
+		return nil, invalidParameterCombination("DBInstance %s is a member of a DB cluster and cannot have read replicas.", sid)
+	}
+	id := aws.ToString(params.DBInstanceIdentifier)
+	if id == "" {
+		return nil, missingParameter("DBInstanceIdentifier")
+	}
+	if _, ok := f.instances[id]; ok {
+		return nil, &types.DBInstanceAlreadyExistsFault{Message: aws.String(fmt.Sprintf("DBInstance %s already exists.", id))}
+	}
+	region, _, err := f.sourceRegion(sid)
+	if err != nil {
+		return nil, err
+	}
+	crossRegion := region != f.region
+	if crossRegion && aws.ToString(params.SourceRegion) == "" {
+		return nil, missingParameter("SourceRegion")
+	}
+	if crossRegion && source.StorageEncrypted && aws.ToString(params.KmsKeyId) == "" {
+		return nil, invalidParameterValue("KmsKeyId is required to create an encrypted read replica in another region.")
+	}
+
+	i := &dbInstance{DBInstance: source, transitions: []string{StatusAvailable}}
+	i.DBInstanceIdentifier = aws.String(id)
+	i.DBInstanceArn = aws.String(f.arn("db", id))
+	i.DbiResourceId = aws.String(f.nextID("db"))
+	i.DBInstanceStatus = aws.String(StatusCreating)
+	i.InstanceCreateTime = now()
+	i.ReadReplicaSourceDBInstanceIdentifier = aws.String(sid)
+	i.ReadReplicaDBInstanceIdentifiers = nil
+	i.StatusInfos = []types.DBInstanceStatusInfo{{
+		StatusType: aws.String("read replication"),
+		Status:     aws.String("replicating"),
+		Normal:     true,
+	}}
+	i.PendingModifiedValues = nil
+	i.Endpoint = &types.Endpoint{Address: aws.String(f.host(id, "")), Port: source.DbInstancePort}
+	i.TagList = params.Tags
+	i.MultiAZ = aws.ToBool(params.MultiAZ)
+	i.PubliclyAccessible = aws.ToBool(params.PubliclyAccessible)
+	i.AvailabilityZone = params.AvailabilityZone
+	i.VpcSecurityGroups = nil
+	i.DBSubnetGroup = nil
+	if params.DBInstanceClass != nil {
+		i.DBInstanceClass = params.DBInstanceClass
+	}
+	if params.StorageType != nil {
+		i.StorageType = params.StorageType
+	}
+	if params.Iops != nil {
+		i.Iops = params.Iops
+	}
+	if params.MaxAllocatedStorage != nil {
+		i.MaxAllocatedStorage = params.MaxAllocatedStorage
+	}
+	if params.DeletionProtection != nil {
+		i.DeletionProtection = aws.ToBool(params.DeletionProtection)
+	}
+	if params.KmsKeyId != nil {
+		i.KmsKeyId = params.KmsKeyId
+	}
+	if port := aws.ToInt32(params.Port); port != 0 {
+		i.DbInstancePort = port
+		i.Endpoint.Port = port
+	}
+	for _, sg := range params.VpcSecurityGroupIds {
+		i.VpcSecurityGroups = append(i.VpcSecurityGroups, types.VpcSecurityGroupMembership{
+			VpcSecurityGroupId: aws.String(sg),
+			Status:             aws.String("active"),
+		})
+	}
+	if name := aws.ToString(params.DBSubnetGroupName); name != "" {
+		sg, ok := f.subnetGroups[name]
+		if !ok {
+			return nil, subnetGroupNotFound(name)
+		}
+		i.DBSubnetGroup = sg
+	}
+
+	f.instances[id] = i
+	out := i.DBInstance
+	return &out, nil
+}
+
+func (f *RDS) PromoteReadReplica(_ context.Context, params *rds.PromoteReadReplicaInput, _ ...func(*rds.Options)) (*rds.PromoteReadReplicaOutput, error) {
+	f.mu.Lock()
+	id := aws.ToString(params.DBInstanceIdentifier)
+	i, ok := f.instances[id]
+	if !ok {
+		f.mu.Unlock()
+		return nil, instanceNotFound(id)
+	}
+	source := aws.ToString(i.ReadReplicaSourceDBInstanceIdentifier)
+	if source == "" {
+		f.mu.Unlock()
+		return nil, &types.InvalidDBInstanceStateFault{Message: aws.String(fmt.Sprintf("DBInstance %s is not a read replica.", id))}
+	}
+	if status := aws.ToString(i.DBInstanceStatus); status != StatusAvailable {
+		f.mu.Unlock()
+		return nil, invalidInstanceState(id, status)
+	}
+
+	promote(i)
+	if params.BackupRetentionPeriod != nil {
+		i.BackupRetentionPeriod = aws.ToInt32(params.BackupRetentionPeriod)
+	}
+	i.DBInstanceStatus = aws.String(StatusModifying)
+	i.transitions = []string{StatusRebooting, StatusAvailable}
+	out := i.DBInstance
+	f.mu.Unlock()
+
+	f.linkReplica(source, id, false)
+	return &rds.PromoteReadReplicaOutput{DBInstance: &out}, nil
+}
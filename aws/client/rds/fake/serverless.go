@@ -0,0 +1,123 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"math"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+const (
+	engineModeServerless      = "serverless"
+	dbInstanceClassServerless = "db.serverless"
+)
+
+// setScaling validates and stores the serverless scaling configurations of
+// the cluster, nil configurations are left unchanged.
+func setScaling(c *dbCluster, v1 *types.ScalingConfiguration, v2 *types.ServerlessV2ScalingConfiguration) error {
+	serverless := aws.ToString(c.EngineMode) == engineModeServerless
+	if (v1 != nil || v2 != nil || serverless) && !isAurora(aws.ToString(c.Engine)) {
+		return invalidParameterCombination("Serverless is only supported by Aurora DB clusters.")
+	}
+
+	if v2 != nil {
+		if serverless {
+			return invalidParameterCombination("ServerlessV2ScalingConfiguration requires the provisioned engine mode.")
+		}
+		min, max := aws.ToFloat64(v2.MinCapacity), aws.ToFloat64(v2.MaxCapacity)
+		if min < 0.5 || max > 128 || min > max || math.Mod(min*2, 1) != 0 || math.Mod(max*2, 1) != 0 {
+			return invalidParameterValue("Invalid ServerlessV2ScalingConfiguration: capacities must be between 0.5 and 128 ACUs by steps of 0.5, with MinCapacity <= MaxCapacity.")
+		}
+		c.ServerlessV2ScalingConfiguration = &types.ServerlessV2ScalingConfigurationInfo{
+			MinCapacity: aws.Float64(min),
+			MaxCapacity: aws.Float64(max),
+		}
+	}
+
+	if v1 != nil && !serverless {
+		return invalidParameterCombination("ScalingConfiguration requires the serverless engine mode.")
+	}
+	if !serverless {
+		return nil
+	}
+	info := c.ScalingConfigurationInfo
+	if info == nil {
+		// The defaults of RDS.
+		info = &types.ScalingConfigurationInfo{
+			MinCapacity:           aws.Int32(1),
+			MaxCapacity:           aws.Int32(16),
+			AutoPause:             aws.Bool(true),
+			SecondsUntilAutoPause: aws.Int32(300),
+			SecondsBeforeTimeout:  aws.Int32(300),
+			TimeoutAction:         aws.String("RollbackCapacityChange"),
+		}
+	}
+	if v1 != nil {
+		next := *info
+		if v1.MinCapacity != nil {
+			next.MinCapacity = v1.MinCapacity
+		}
+		if v1.MaxCapacity != nil {
+			next.MaxCapacity = v1.MaxCapacity
+		}
+		if v1.AutoPause != nil {
+			next.AutoPause = v1.AutoPause
+		}
+		if v1.SecondsUntilAutoPause != nil {
+			next.SecondsUntilAutoPause = v1.SecondsUntilAutoPause
+		}
+		if v1.SecondsBeforeTimeout != nil {
+			next.SecondsBeforeTimeout = v1.SecondsBeforeTimeout
+		}
+		if v1.TimeoutAction != nil {
+			next.TimeoutAction = v1.TimeoutAction
+		}
+		min, max := aws.ToInt32(next.MinCapacity), aws.ToInt32(next.MaxCapacity)
+		if !powerOfTwo(min) || !powerOfTwo(max) || max > 256 || min > max {
+			return invalidParameterValue("Invalid ScalingConfiguration: capacities must be powers of 2 up to 256, with MinCapacity <= MaxCapacity.")
+		}
+		info = &next
+	}
+	c.ScalingConfigurationInfo = info
+
+	// Keep the current capacity within the new range.
+	capacity := aws.ToInt32(c.Capacity)
+	if capacity < aws.ToInt32(info.MinCapacity) {
+		capacity = aws.ToInt32(info.MinCapacity)
+	}
+	if capacity > aws.ToInt32(info.MaxCapacity) {
+		capacity = aws.ToInt32(info.MaxCapacity)
+	}
+	c.Capacity = aws.Int32(capacity)
+	return nil
+}
+
+func powerOfTwo(n int32) bool {
+	return n > 0 && n&(n-1) == 0
+}
+
+// checkServerlessInstance checks that an instance of class can be added to
+// the cluster.
+func checkServerlessInstance(c *dbCluster, class string) error {
+	if aws.ToString(c.EngineMode) == engineModeServerless {
+		return invalidParameterCombination("DB instances cannot be added to Aurora Serverless v1 DB clusters.")
+	}
+	if class == dbInstanceClassServerless && c.ServerlessV2ScalingConfiguration == nil {
+		return invalidParameterCombination("Set the ServerlessV2ScalingConfiguration of DBCluster %s to add db.serverless instances.", aws.ToString(c.DBClusterIdentifier))
+	}
+	return nil
+}
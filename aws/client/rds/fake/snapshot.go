@@ -0,0 +1,358 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+const snapshotTypeManual = "manual"
+
+func snapshotNotFound(id string) error {
+	return &types.DBSnapshotNotFoundFault{Message: aws.String(fmt.Sprintf("DBSnapshot %s not found.", id))}
+}
+
+func snapshotAlreadyExists(id string) error {
+	return &types.DBSnapshotAlreadyExistsFault{Message: aws.String(fmt.Sprintf("Cannot create the snapshot because a snapshot with the identifier %s already exists.", id))}
+}
+
+func invalidSnapshotState(id, status string) error {
+	return &types.InvalidDBSnapshotStateFault{Message: aws.String(fmt.Sprintf("DBSnapshot %s is in %s state.", id, status))}
+}
+
+// newSnapshot returns a snapshot of the instance, which is created when the
+// returned snapshot is added to f.snapshots.
+func (f *RDS) newSnapshot(i *dbInstance, id string, tags []types.Tag) *dbSnapshot {
+	return &dbSnapshot{DBSnapshot: types.DBSnapshot{
+		DBSnapshotIdentifier: aws.String(id),
+		DBSnapshotArn:        aws.String(f.arn("snapshot", id)),
+		DBInstanceIdentifier: i.DBInstanceIdentifier,
+		DbiResourceId:        i.DbiResourceId,
+		Engine:               i.Engine,
+		EngineVersion:        i.EngineVersion,
+		AllocatedStorage:     i.AllocatedStorage,
+		AvailabilityZone:     i.AvailabilityZone,
+		Encrypted:            i.StorageEncrypted,
+		KmsKeyId:             i.KmsKeyId,
+		Iops:                 i.Iops,
+		StorageType:          i.StorageType,
+		LicenseModel:         i.LicenseModel,
+		MasterUsername:       i.MasterUsername,
+		Port:                 i.DbInstancePort,
+		InstanceCreateTime:   i.InstanceCreateTime,
+		SnapshotCreateTime:   now(),
+		SnapshotType:         aws.String(snapshotTypeManual),
+		Status:               aws.String(StatusCreating),
+		TagList:              tags,
+		VpcId:                aws.String(FakeVpcID),
+	}, transitions: []string{StatusAvailable}, instanceClass: i.DBInstanceClass}
+}
+
+func (f *RDS) CreateDBSnapshot(_ context.Context, params *rds.CreateDBSnapshotInput, _ ...func(*rds.Options)) (*rds.CreateDBSnapshotOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := aws.ToString(params.DBSnapshotIdentifier)
+	if id == "" {
+		return nil, missingParameter("DBSnapshotIdentifier")
+	}
+	if _, ok := f.snapshots[id]; ok {
+		return nil, snapshotAlreadyExists(id)
+	}
+	iid := aws.ToString(params.DBInstanceIdentifier)
+	i, ok := f.instances[iid]
+	if !ok {
+		return nil, instanceNotFound(iid)
+	}
+	if status := aws.ToString(i.DBInstanceStatus); status != StatusAvailable {
+		return nil, invalidInstanceState(iid, status)
+	}
+	if aws.ToString(i.DBClusterIdentifier) != "" {
+		return nil, invalidParameterCombination("The specified instance is a member of a cluster and a snapshot cannot be created directly. Please use the CreateDBClusterSnapshot API instead.")
+	}
+
+	s := f.newSnapshot(i, id, params.Tags)
+	f.snapshots[id] = s
+	out := s.DBSnapshot
+	return &rds.CreateDBSnapshotOutput{DBSnapshot: &out}, nil
+}
+
+func (f *RDS) DescribeDBSnapshots(_ context.Context, params *rds.DescribeDBSnapshotsInput, _ ...func(*rds.Options)) (*rds.DescribeDBSnapshotsOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.describe()
+
+	out := &rds.DescribeDBSnapshotsOutput{}
+	if id := aws.ToString(params.DBSnapshotIdentifier); id != "" {
+		s, ok := f.snapshots[id]
+		if !ok {
+			return nil, snapshotNotFound(id)
+		}
+		// The other filters still apply, a snapshot of another instance is
+		// not returned.
+		if matchSnapshot(s, params) {
+			out.DBSnapshots = append(out.DBSnapshots, s.DBSnapshot)
+		}
+		return out, nil
+	}
+
+	ids := []string{}
+	for _, id := range sortedKeys(f.snapshots) {
+		if matchSnapshot(f.snapshots[id], params) {
+			ids = append(ids, id)
+		}
+	}
+	page, marker, err := paginate(ids, params.Marker, params.MaxRecords)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range page {
+		out.DBSnapshots = append(out.DBSnapshots, f.snapshots[id].DBSnapshot)
+	}
+	out.Marker = marker
+	return out, nil
+}
+
+// matchSnapshot reports whether the snapshot matches the instance and type
+// filters.
+func matchSnapshot(s *dbSnapshot, params *rds.DescribeDBSnapshotsInput) bool {
+	if iid := aws.ToString(params.DBInstanceIdentifier); iid != "" && iid != aws.ToString(s.DBInstanceIdentifier) {
+		return false
+	}
+	if t := aws.ToString(params.SnapshotType); t != "" && t != aws.ToString(s.SnapshotType) {
+		return false
+	}
+	return true
+}
+
+// sourceSnapshot returns a copy of the snapshot identified by an identifier
+// or an ARN, looking it up in the peer of its region if needed.
+func (f *RDS) sourceSnapshot(source string) (dbSnapshot, error) {
+	f.mu.Lock()
+	region := f.region
+	id := source
+	if arn.IsARN(source) {
+		a, err := arn.Parse(source)
+		if err != nil {
+			f.mu.Unlock()
+			return dbSnapshot{}, invalidParameterValue("Invalid snapshot identifier: %s", source)
+		}
+		region = a.Region
+		id = a.Resource[strings.LastIndex(a.Resource, ":")+1:]
+	}
+	if region == f.region {
+		defer f.mu.Unlock()
+		s, ok := f.snapshots[id]
+		if !ok {
+			return dbSnapshot{}, snapshotNotFound(source)
+		}
+		return dbSnapshot{DBSnapshot: s.DBSnapshot, instanceClass: s.instanceClass}, nil
+	}
+
+	peer, ok := f.peers[region]
+	f.mu.Unlock()
+	if !ok {
+		return dbSnapshot{}, snapshotNotFound(source)
+	}
+	return peer.sourceSnapshot(id)
+}
+
+func (f *RDS) CopyDBSnapshot(_ context.Context, params *rds.CopyDBSnapshotInput, _ ...func(*rds.Options)) (*rds.CopyDBSnapshotOutput, error) {
+	sid := aws.ToString(params.SourceDBSnapshotIdentifier)
+	if sid == "" {
+		return nil, missingParameter("SourceDBSnapshotIdentifier")
+	}
+	source, err := f.sourceSnapshot(sid)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if status := aws.ToString(source.Status); status != StatusAvailable {
+		return nil, invalidSnapshotState(sid, status)
+	}
+	id := aws.ToString(params.TargetDBSnapshotIdentifier)
+	if id == "" {
+		return nil, missingParameter("TargetDBSnapshotIdentifier")
+	}
+	if _, ok := f.snapshots[id]; ok {
+		return nil, snapshotAlreadyExists(id)
+	}
+	crossRegion := arn.IsARN(sid) && !strings.HasPrefix(sid, fmt.Sprintf("arn:aws:rds:%s:", f.region))
+	if crossRegion && source.Encrypted && aws.ToString(params.KmsKeyId) == "" {
+		return nil, invalidParameterValue("KmsKeyId is required to copy an encrypted snapshot to another region.")
+	}
+
+	s := &dbSnapshot{DBSnapshot: source.DBSnapshot, transitions: []string{StatusAvailable}, instanceClass: source.instanceClass}
+	s.DBSnapshotIdentifier = aws.String(id)
+	s.DBSnapshotArn = aws.String(f.arn("snapshot", id))
+	s.SnapshotType = aws.String(snapshotTypeManual)
+	s.Status = aws.String(StatusCreating)
+	s.PercentProgress = 0
+	s.SourceDBSnapshotIdentifier = source.DBSnapshotArn
+	s.OriginalSnapshotCreateTime = source.SnapshotCreateTime
+	s.SnapshotCreateTime = now()
+	s.TagList = params.Tags
+	if aws.ToBool(params.CopyTags) {
+		s.TagList = append(append([]types.Tag(nil), source.TagList...), params.Tags...)
+	}
+	if crossRegion {
+		a, _ := arn.Parse(sid)
+		s.SourceRegion = aws.String(a.Region)
+	}
+	if params.KmsKeyId != nil {
+		s.KmsKeyId = params.KmsKeyId
+	}
+
+	f.snapshots[id] = s
+	out := s.DBSnapshot
+	return &rds.CopyDBSnapshotOutput{DBSnapshot: &out}, nil
+}
+
+func (f *RDS) ModifyDBSnapshotAttribute(_ context.Context, params *rds.ModifyDBSnapshotAttributeInput, _ ...func(*rds.Options)) (*rds.ModifyDBSnapshotAttributeOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := aws.ToString(params.DBSnapshotIdentifier)
+	s, ok := f.snapshots[id]
+	if !ok {
+		return nil, snapshotNotFound(id)
+	}
+	if name := aws.ToString(params.AttributeName); name != "restore" {
+		return nil, invalidParameterValue("Invalid attribute name: %s", name)
+	}
+	if aws.ToString(s.SnapshotType) != snapshotTypeManual {
+		return nil, invalidSnapshotState(id, aws.ToString(s.SnapshotType))
+	}
+
+	restore := []string{}
+	for _, account := range s.restore {
+		if !contains(params.ValuesToRemove, account) {
+			restore = append(restore, account)
+		}
+	}
+	for _, account := range params.ValuesToAdd {
+		if !contains(restore, account) {
+			restore = append(restore, account)
+		}
+	}
+	s.restore = restore
+
+	return &rds.ModifyDBSnapshotAttributeOutput{DBSnapshotAttributesResult: &types.DBSnapshotAttributesResult{
+		DBSnapshotIdentifier: aws.String(id),
+		DBSnapshotAttributes: []types.DBSnapshotAttribute{{
+			AttributeName:   aws.String("restore"),
+			AttributeValues: append([]string(nil), restore...),
+		}},
+	}}, nil
+}
+
+func (f *RDS) DeleteDBSnapshot(_ context.Context, params *rds.DeleteDBSnapshotInput, _ ...func(*rds.Options)) (*rds.DeleteDBSnapshotOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := aws.ToString(params.DBSnapshotIdentifier)
+	s, ok := f.snapshots[id]
+	if !ok {
+		return nil, snapshotNotFound(id)
+	}
+	if status := aws.ToString(s.Status); status != StatusAvailable {
+		return nil, invalidSnapshotState(id, status)
+	}
+
+	delete(f.snapshots, id)
+	out := s.DBSnapshot
+	out.Status = aws.String("deleted")
+	return &rds.DeleteDBSnapshotOutput{DBSnapshot: &out}, nil
+}
+
+func (f *RDS) RestoreDBInstanceFromDBSnapshot(_ context.Context, params *rds.RestoreDBInstanceFromDBSnapshotInput, _ ...func(*rds.Options)) (*rds.RestoreDBInstanceFromDBSnapshotOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sid := aws.ToString(params.DBSnapshotIdentifier)
+	s, ok := f.snapshots[sid]
+	if !ok {
+		return nil, snapshotNotFound(sid)
+	}
+	if status := aws.ToString(s.Status); status != StatusAvailable {
+		return nil, invalidSnapshotState(sid, status)
+	}
+	id := aws.ToString(params.DBInstanceIdentifier)
+	if id == "" {
+		return nil, missingParameter("DBInstanceIdentifier")
+	}
+	if _, ok := f.instances[id]; ok {
+		return nil, &types.DBInstanceAlreadyExistsFault{Message: aws.String(fmt.Sprintf("DBInstance %s already exists.", id))}
+	}
+
+	port := s.Port
+	if params.Port != nil {
+		port = aws.ToInt32(params.Port)
+	}
+	i := &dbInstance{DBInstance: types.DBInstance{
+		DBInstanceIdentifier: aws.String(id),
+		DBInstanceArn:        aws.String(f.arn("db", id)),
+		DbiResourceId:        aws.String(f.nextID("db")),
+		DBInstanceClass:      params.DBInstanceClass,
+		Engine:               s.Engine,
+		EngineVersion:        s.EngineVersion,
+		MasterUsername:       s.MasterUsername,
+		AllocatedStorage:     s.AllocatedStorage,
+		Iops:                 s.Iops,
+		StorageType:          s.StorageType,
+		StorageEncrypted:     s.Encrypted,
+		KmsKeyId:             s.KmsKeyId,
+		LicenseModel:         s.LicenseModel,
+		MultiAZ:              aws.ToBool(params.MultiAZ),
+		AvailabilityZone:     params.AvailabilityZone,
+		PubliclyAccessible:   aws.ToBool(params.PubliclyAccessible),
+		DeletionProtection:   aws.ToBool(params.DeletionProtection),
+		DbInstancePort:       port,
+		Endpoint:             &types.Endpoint{Address: aws.String(f.host(id, "")), Port: port},
+		TagList:              params.Tags,
+		DBInstanceStatus:     aws.String(StatusCreating),
+		InstanceCreateTime:   now(),
+	}, transitions: []string{StatusAvailable}}
+	if i.DBInstanceClass == nil {
+		i.DBInstanceClass = s.instanceClass
+	}
+	for _, sg := range params.VpcSecurityGroupIds {
+		i.VpcSecurityGroups = append(i.VpcSecurityGroups, types.VpcSecurityGroupMembership{
+			VpcSecurityGroupId: aws.String(sg),
+			Status:             aws.String("active"),
+		})
+	}
+	if name := aws.ToString(params.DBSubnetGroupName); name != "" {
+		sg, ok := f.subnetGroups[name]
+		if !ok {
+			return nil, subnetGroupNotFound(name)
+		}
+		i.DBSubnetGroup = sg
+	}
+
+	f.instances[id] = i
+	out := i.DBInstance
+	return &rds.RestoreDBInstanceFromDBSnapshotOutput{DBInstance: &out}, nil
+}
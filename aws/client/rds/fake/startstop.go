@@ -0,0 +1,169 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+)
+
+// autoRestartAfter is how long RDS keeps a database stopped.
+const autoRestartAfter = 7 * 24 * time.Hour
+
+func restartTime() *time.Time {
+	t := now().Add(autoRestartAfter)
+	return &t
+}
+
+func (f *RDS) StopDBInstance(_ context.Context, params *rds.StopDBInstanceInput, _ ...func(*rds.Options)) (*rds.StopDBInstanceOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := aws.ToString(params.DBInstanceIdentifier)
+	i, ok := f.instances[id]
+	if !ok {
+		return nil, instanceNotFound(id)
+	}
+	if status := aws.ToString(i.DBInstanceStatus); status != StatusAvailable {
+		return nil, invalidInstanceState(id, status)
+	}
+	if aws.ToString(i.DBClusterIdentifier) != "" {
+		return nil, invalidParameterCombination("The instance %s is part of a DB cluster, stop the DB cluster instead.", id)
+	}
+	if aws.ToString(i.ReadReplicaSourceDBInstanceIdentifier) != "" || len(i.ReadReplicaDBInstanceIdentifiers) > 0 {
+		return nil, invalidParameterCombination("The instance %s has read replicas or is a read replica and cannot be stopped.", id)
+	}
+	if sid := aws.ToString(params.DBSnapshotIdentifier); sid != "" {
+		if _, ok := f.snapshots[sid]; ok {
+			return nil, snapshotAlreadyExists(sid)
+		}
+		f.snapshots[sid] = f.newSnapshot(i, sid, nil)
+	}
+
+	i.DBInstanceStatus = aws.String(StatusStopping)
+	i.transitions = []string{StatusStopped}
+	i.AutomaticRestartTime = restartTime()
+	out := i.DBInstance
+	return &rds.StopDBInstanceOutput{DBInstance: &out}, nil
+}
+
+func (f *RDS) StartDBInstance(_ context.Context, params *rds.StartDBInstanceInput, _ ...func(*rds.Options)) (*rds.StartDBInstanceOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := aws.ToString(params.DBInstanceIdentifier)
+	i, ok := f.instances[id]
+	if !ok {
+		return nil, instanceNotFound(id)
+	}
+	if status := aws.ToString(i.DBInstanceStatus); status != StatusStopped {
+		return nil, invalidInstanceState(id, status)
+	}
+
+	i.start()
+	out := i.DBInstance
+	return &rds.StartDBInstanceOutput{DBInstance: &out}, nil
+}
+
+func (i *dbInstance) start() {
+	i.DBInstanceStatus = aws.String(StatusStarting)
+	i.transitions = []string{StatusAvailable}
+	i.AutomaticRestartTime = nil
+}
+
+func (f *RDS) StopDBCluster(_ context.Context, params *rds.StopDBClusterInput, _ ...func(*rds.Options)) (*rds.StopDBClusterOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := aws.ToString(params.DBClusterIdentifier)
+	c, ok := f.clusters[id]
+	if !ok {
+		return nil, clusterNotFound(id)
+	}
+	if status := aws.ToString(c.Status); status != StatusAvailable {
+		return nil, invalidClusterState(id, status)
+	}
+	if aws.ToString(c.EngineMode) == engineModeServerless {
+		return nil, invalidParameterCombination("Aurora Serverless v1 DB clusters cannot be stopped, they pause on their own.")
+	}
+	if err := f.checkGlobalMember(c); err != nil {
+		return nil, err
+	}
+	for _, m := range c.DBClusterMembers {
+		i := f.instances[aws.ToString(m.DBInstanceIdentifier)]
+		if status := aws.ToString(i.DBInstanceStatus); status != StatusAvailable {
+			return nil, invalidInstanceState(aws.ToString(i.DBInstanceIdentifier), status)
+		}
+	}
+
+	c.Status = aws.String(StatusStopping)
+	c.transitions = []string{StatusStopped}
+	c.AutomaticRestartTime = restartTime()
+	for _, m := range c.DBClusterMembers {
+		i := f.instances[aws.ToString(m.DBInstanceIdentifier)]
+		i.DBInstanceStatus = aws.String(StatusStopping)
+		i.transitions = []string{StatusStopped}
+	}
+	out := c.copy()
+	return &rds.StopDBClusterOutput{DBCluster: &out}, nil
+}
+
+func (f *RDS) StartDBCluster(_ context.Context, params *rds.StartDBClusterInput, _ ...func(*rds.Options)) (*rds.StartDBClusterOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := aws.ToString(params.DBClusterIdentifier)
+	c, ok := f.clusters[id]
+	if !ok {
+		return nil, clusterNotFound(id)
+	}
+	if status := aws.ToString(c.Status); status != StatusStopped {
+		return nil, invalidClusterState(id, status)
+	}
+
+	f.startCluster(c)
+	out := c.copy()
+	return &rds.StartDBClusterOutput{DBCluster: &out}, nil
+}
+
+func (f *RDS) startCluster(c *dbCluster) {
+	c.Status = aws.String(StatusStarting)
+	c.transitions = []string{StatusAvailable}
+	c.AutomaticRestartTime = nil
+	for _, m := range c.DBClusterMembers {
+		f.instances[aws.ToString(m.DBInstanceIdentifier)].start()
+	}
+}
+
+// AutoRestart starts the stopped instances and clusters, as RDS does once
+// they have been stopped for seven days.
+func (f *RDS) AutoRestart() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, c := range f.clusters {
+		if aws.ToString(c.Status) == StatusStopped {
+			f.startCluster(c)
+		}
+	}
+	for _, i := range f.instances {
+		if aws.ToString(i.DBInstanceStatus) == StatusStopped {
+			i.start()
+		}
+	}
+}
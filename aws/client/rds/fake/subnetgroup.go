@@ -0,0 +1,154 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+const subnetGroupStatusComplete = "Complete"
+
+// FakeVpcID is the VPC of every subnet group of the fake.
+const FakeVpcID = "vpc-fake"
+
+func subnetGroupNotFound(name string) error {
+	return &types.DBSubnetGroupNotFoundFault{Message: aws.String(fmt.Sprintf("DBSubnetGroup %s not found.", name))}
+}
+
+// subnets lists the subnets once each, RDS ignores duplicated IDs.
+func subnets(ids []string) []types.Subnet {
+	subnets := []types.Subnet{}
+	seen := map[string]struct{}{}
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		subnets = append(subnets, types.Subnet{
+			SubnetIdentifier: aws.String(id),
+			SubnetStatus:     aws.String("Active"),
+		})
+	}
+	return subnets
+}
+
+func (f *RDS) CreateDBSubnetGroup(_ context.Context, params *rds.CreateDBSubnetGroupInput, _ ...func(*rds.Options)) (*rds.CreateDBSubnetGroupOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	name := aws.ToString(params.DBSubnetGroupName)
+	if name == "" {
+		return nil, missingParameter("DBSubnetGroupName")
+	}
+	if aws.ToString(params.DBSubnetGroupDescription) == "" {
+		return nil, missingParameter("DBSubnetGroupDescription")
+	}
+	if len(params.SubnetIds) == 0 {
+		return nil, missingParameter("SubnetIds")
+	}
+	if _, ok := f.subnetGroups[name]; ok {
+		return nil, &types.DBSubnetGroupAlreadyExistsFault{Message: aws.String(fmt.Sprintf("DBSubnetGroup %s already exists.", name))}
+	}
+
+	sg := &types.DBSubnetGroup{
+		DBSubnetGroupName:        aws.String(name),
+		DBSubnetGroupDescription: params.DBSubnetGroupDescription,
+		DBSubnetGroupArn:         aws.String(f.arn("subgrp", name)),
+		SubnetGroupStatus:        aws.String(subnetGroupStatusComplete),
+		VpcId:                    aws.String(FakeVpcID),
+		Subnets:                  subnets(params.SubnetIds),
+	}
+
+	f.subnetGroups[name] = sg
+	f.tags[aws.ToString(sg.DBSubnetGroupArn)] = params.Tags
+	out := *sg
+	return &rds.CreateDBSubnetGroupOutput{DBSubnetGroup: &out}, nil
+}
+
+func (f *RDS) DescribeDBSubnetGroups(_ context.Context, params *rds.DescribeDBSubnetGroupsInput, _ ...func(*rds.Options)) (*rds.DescribeDBSubnetGroupsOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	out := &rds.DescribeDBSubnetGroupsOutput{}
+	if name := aws.ToString(params.DBSubnetGroupName); name != "" {
+		sg, ok := f.subnetGroups[name]
+		if !ok {
+			return nil, subnetGroupNotFound(name)
+		}
+		out.DBSubnetGroups = append(out.DBSubnetGroups, *sg)
+		return out, nil
+	}
+
+	page, marker, err := paginate(sortedKeys(f.subnetGroups), params.Marker, params.MaxRecords)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range page {
+		out.DBSubnetGroups = append(out.DBSubnetGroups, *f.subnetGroups[name])
+	}
+	out.Marker = marker
+	return out, nil
+}
+
+func (f *RDS) ModifyDBSubnetGroup(_ context.Context, params *rds.ModifyDBSubnetGroupInput, _ ...func(*rds.Options)) (*rds.ModifyDBSubnetGroupOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	name := aws.ToString(params.DBSubnetGroupName)
+	sg, ok := f.subnetGroups[name]
+	if !ok {
+		return nil, subnetGroupNotFound(name)
+	}
+	if len(params.SubnetIds) == 0 {
+		return nil, missingParameter("SubnetIds")
+	}
+
+	if params.DBSubnetGroupDescription != nil {
+		sg.DBSubnetGroupDescription = params.DBSubnetGroupDescription
+	}
+	sg.Subnets = subnets(params.SubnetIds)
+	out := *sg
+	return &rds.ModifyDBSubnetGroupOutput{DBSubnetGroup: &out}, nil
+}
+
+func (f *RDS) DeleteDBSubnetGroup(_ context.Context, params *rds.DeleteDBSubnetGroupInput, _ ...func(*rds.Options)) (*rds.DeleteDBSubnetGroupOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	name := aws.ToString(params.DBSubnetGroupName)
+	sg, ok := f.subnetGroups[name]
+	if !ok {
+		return nil, subnetGroupNotFound(name)
+	}
+	for _, id := range sortedKeys(f.instances) {
+		if i := f.instances[id]; i.DBSubnetGroup != nil && aws.ToString(i.DBSubnetGroup.DBSubnetGroupName) == name {
+			return nil, &types.InvalidDBSubnetGroupStateFault{Message: aws.String(fmt.Sprintf("Cannot delete the subnet group '%s' because at least one database instance: %s is still using it.", name, id))}
+		}
+	}
+	for _, id := range sortedKeys(f.clusters) {
+		if aws.ToString(f.clusters[id].DBSubnetGroup) == name {
+			return nil, &types.InvalidDBSubnetGroupStateFault{Message: aws.String(fmt.Sprintf("Cannot delete the subnet group '%s' because at least one database cluster: %s is still using it.", name, id))}
+		}
+	}
+
+	delete(f.subnetGroups, name)
+	delete(f.tags, aws.ToString(sg.DBSubnetGroupArn))
+	return &rds.DeleteDBSubnetGroupOutput{}, nil
+}
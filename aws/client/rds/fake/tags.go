@@ -0,0 +1,147 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/aws/smithy-go"
+)
+
+// maxTags is the number of tags RDS allows per resource.
+const maxTags = 50
+
+// resourceTags returns the tags of the resource named by the ARN and a
+// function replacing them. Resources whose RDS type has no tag list keep
+// their tags in f.tags. It is called with f.mu held.
+func (f *RDS) resourceTags(arn string) ([]types.Tag, func([]types.Tag), error) {
+	parts := strings.SplitN(arn, ":", 7)
+	if len(parts) != 7 || parts[0] != "arn" || parts[2] != "rds" {
+		return nil, nil, invalidParameterValue("The specified resource name does not match an RDS resource in this region.")
+	}
+	if parts[3] != f.region || parts[4] != f.account {
+		return nil, nil, invalidParameterValue("The specified resource name does not match an RDS resource in this region.")
+	}
+	resource, id := parts[5], parts[6]
+
+	switch resource {
+	case "db":
+		i, ok := f.instances[id]
+		if !ok {
+			return nil, nil, instanceNotFound(id)
+		}
+		return i.TagList, func(tags []types.Tag) { i.TagList = tags }, nil
+	case "cluster":
+		c, ok := f.clusters[id]
+		if !ok {
+			return nil, nil, clusterNotFound(id)
+		}
+		return c.TagList, func(tags []types.Tag) { c.TagList = tags }, nil
+	case "snapshot":
+		s, ok := f.snapshots[id]
+		if !ok {
+			return nil, nil, snapshotNotFound(id)
+		}
+		return s.TagList, func(tags []types.Tag) { s.TagList = tags }, nil
+	case "cluster-snapshot":
+		s, ok := f.clusterSnapshots[id]
+		if !ok {
+			return nil, nil, clusterSnapshotNotFound(id)
+		}
+		return s.TagList, func(tags []types.Tag) { s.TagList = tags }, nil
+	case "cluster-endpoint":
+		if _, ok := f.endpoints[id]; !ok {
+			return nil, nil, endpointNotFound(id)
+		}
+	case "pg", "cluster-pg":
+		if _, err := f.parameterGroup(id, resource == "cluster-pg"); err != nil {
+			return nil, nil, err
+		}
+	case "subgrp":
+		if _, ok := f.subnetGroups[id]; !ok {
+			return nil, nil, subnetGroupNotFound(id)
+		}
+	default:
+		return nil, nil, invalidParameterValue("Unsupported resource type %s.", resource)
+	}
+	return f.tags[arn], func(tags []types.Tag) { f.tags[arn] = tags }, nil
+}
+
+func (f *RDS) AddTagsToResource(_ context.Context, params *rds.AddTagsToResourceInput, _ ...func(*rds.Options)) (*rds.AddTagsToResourceOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	current, set, err := f.resourceTags(aws.ToString(params.ResourceName))
+	if err != nil {
+		return nil, err
+	}
+	tags := append([]types.Tag(nil), current...)
+	for _, t := range params.Tags {
+		if aws.ToString(t.Key) == "" {
+			return nil, missingParameter("Tags.Key")
+		}
+		found := false
+		for n := range tags {
+			if aws.ToString(tags[n].Key) == aws.ToString(t.Key) {
+				tags[n].Value = t.Value
+				found = true
+			}
+		}
+		if !found {
+			tags = append(tags, t)
+		}
+	}
+	if len(tags) > maxTags {
+		return nil, &smithy.GenericAPIError{Code: "TagQuotaPerResourceExceeded", Message: fmt.Sprintf("Cannot add more than %d tags to a resource.", maxTags), Fault: smithy.FaultClient}
+	}
+
+	set(tags)
+	return &rds.AddTagsToResourceOutput{}, nil
+}
+
+func (f *RDS) RemoveTagsFromResource(_ context.Context, params *rds.RemoveTagsFromResourceInput, _ ...func(*rds.Options)) (*rds.RemoveTagsFromResourceOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	current, set, err := f.resourceTags(aws.ToString(params.ResourceName))
+	if err != nil {
+		return nil, err
+	}
+	tags := []types.Tag{}
+	for _, t := range current {
+		if !contains(params.TagKeys, aws.ToString(t.Key)) {
+			tags = append(tags, t)
+		}
+	}
+
+	set(tags)
+	return &rds.RemoveTagsFromResourceOutput{}, nil
+}
+
+func (f *RDS) ListTagsForResource(_ context.Context, params *rds.ListTagsForResourceInput, _ ...func(*rds.Options)) (*rds.ListTagsForResourceOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tags, _, err := f.resourceTags(aws.ToString(params.ResourceName))
+	if err != nil {
+		return nil, err
+	}
+	return &rds.ListTagsForResourceOutput{TagList: append([]types.Tag{}, tags...)}, nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+)
+
+// ReaderSpec describes a reader instance of an Aurora cluster. Empty fields
+// take defaults, see AddReaders.
+type ReaderSpec struct {
+	DBInstanceIdentifier string
+	DBInstanceClass      string
+	AvailabilityZone     string
+	// PromotionTier orders the readers on failover, from 0 to 15 where 0 is
+	// promoted first.
+	PromotionTier *int32
+}
+
+// AddReaders adds a reader instance to the cluster for each spec. Readers
+// without identifier are named after the cluster, e.g. foo-reader-1, and
+// readers without class get the one set by SetDBInstanceClass or else the
+// class of the writer.
+func (s *rdsAurora) AddReaders(ctx context.Context, specs ...ReaderSpec) error {
+	desc, err := describeCluster(ctx, s.core, s.describeClusterParam)
+	if err != nil {
+		return err
+	}
+	return s.addReaders(ctx, desc, specs)
+}
+
+func (s *rdsAurora) addReaders(ctx context.Context, desc *DescCluster, specs []ReaderSpec) error {
+	members := map[string]struct{}{}
+	for _, m := range desc.DBClusterMembers {
+		members[m.DBInstanceIdentifier] = struct{}{}
+	}
+	defaultClass := aws.ToString(s.createInstanceParam.DBInstanceClass)
+
+	for _, spec := range specs {
+		id := spec.DBInstanceIdentifier
+		for n := 1; id == ""; n++ {
+			if _, ok := members[fmt.Sprintf("%s-reader-%d", desc.DBClusterIdentifier, n)]; !ok {
+				id = fmt.Sprintf("%s-reader-%d", desc.DBClusterIdentifier, n)
+			}
+		}
+		class := spec.DBInstanceClass
+		if class == "" && defaultClass == "" {
+			writer, err := s.writerClass(ctx, desc)
+			if err != nil {
+				return err
+			}
+			defaultClass = writer
+		}
+		if class == "" {
+			class = defaultClass
+		}
+
+		param := &rds.CreateDBInstanceInput{
+			DBInstanceIdentifier: aws.String(id),
+			DBClusterIdentifier:  aws.String(desc.DBClusterIdentifier),
+			DBInstanceClass:      aws.String(class),
+			Engine:               aws.String(desc.Engine),
+			PromotionTier:        spec.PromotionTier,
+			PubliclyAccessible:   s.createInstanceParam.PubliclyAccessible,
+			DBParameterGroupName: s.createInstanceParam.DBParameterGroupName,
+			Tags:                 s.createInstanceParam.Tags,
+		}
+		if spec.AvailabilityZone != "" {
+			param.AvailabilityZone = aws.String(spec.AvailabilityZone)
+		}
+		if _, err := s.core.CreateDBInstance(ctx, param); err != nil {
+			return err
+		}
+		members[id] = struct{}{}
+	}
+	return nil
+}
+
+func (s *rdsAurora) writerClass(ctx context.Context, desc *DescCluster) (string, error) {
+	writer := desc.Writer()
+	if writer == "" {
+		return "", fmt.Errorf("DB cluster %s has no writer, set the reader class", desc.DBClusterIdentifier)
+	}
+	output, err := s.core.DescribeDBInstances(ctx, &rds.DescribeDBInstancesInput{DBInstanceIdentifier: aws.String(writer)})
+	if err != nil {
+		return "", err
+	}
+	if len(output.DBInstances) == 0 {
+		return "", fmt.Errorf("writer %s of DB cluster %s not found", writer, desc.DBClusterIdentifier)
+	}
+	return aws.ToString(output.DBInstances[0].DBInstanceClass), nil
+}
+
+// RemoveReaders deletes the reader instances. The writer cannot be removed,
+// fail over first.
+func (s *rdsAurora) RemoveReaders(ctx context.Context, ids ...string) error {
+	desc, err := describeCluster(ctx, s.core, s.describeClusterParam)
+	if err != nil {
+		return err
+	}
+	readers := map[string]struct{}{}
+	for _, m := range desc.DBClusterMembers {
+		if !m.IsClusterWrite {
+			readers[m.DBInstanceIdentifier] = struct{}{}
+		}
+	}
+	for _, id := range ids {
+		if _, ok := readers[id]; !ok {
+			return fmt.Errorf("%s is not a reader of DB cluster %s", id, desc.DBClusterIdentifier)
+		}
+	}
+	return s.removeReaders(ctx, ids)
+}
+
+func (s *rdsAurora) removeReaders(ctx context.Context, ids []string) error {
+	for _, id := range ids {
+		_, err := s.core.DeleteDBInstance(ctx, &rds.DeleteDBInstanceInput{
+			DBInstanceIdentifier:   aws.String(id),
+			DeleteAutomatedBackups: s.deleteInstanceParam.DeleteAutomatedBackups,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ScaleReaders adds or removes readers until the cluster has count readers.
+// Added readers take the defaults of AddReaders, removed readers are the
+// ones promoted last on failover.
+// NOTE: The diff is computed from DescCluster.DBClusterMembers, which still
+// lists instances being deleted, so wait for the cluster to be available
+// between two calls.
+func (s *rdsAurora) ScaleReaders(ctx context.Context, count int) error {
+	if count < 0 {
+		return fmt.Errorf("invalid reader count %d", count)
+	}
+	desc, err := describeCluster(ctx, s.core, s.describeClusterParam)
+	if err != nil {
+		return err
+	}
+	readers := []ClusterMember{}
+	for _, m := range desc.DBClusterMembers {
+		if !m.IsClusterWrite {
+			readers = append(readers, m)
+		}
+	}
+
+	if len(readers) < count {
+		return s.addReaders(ctx, desc, make([]ReaderSpec, count-len(readers)))
+	}
+
+	sort.Slice(readers, func(i, j int) bool {
+		if readers[i].PromotionTier != readers[j].PromotionTier {
+			return readers[i].PromotionTier > readers[j].PromotionTier
+		}
+		return readers[i].DBInstanceIdentifier > readers[j].DBInstanceIdentifier
+	})
+	ids := []string{}
+	for _, m := range readers[:len(readers)-count] {
+		ids = append(ids, m.DBInstanceIdentifier)
+	}
+	return s.removeReaders(ctx, ids)
+}
@@ -0,0 +1,90 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/database-mesh/golang-sdk/aws/client/rds/fake"
+)
+
+func Test_ReaderFleet(t *testing.T) {
+	f := fake.New().SetAutoAdvance(true)
+	newAuroraWithReaders(t, f)
+	svc := NewServiceWithAPI(f)
+	aurora := svc.Aurora().SetDBClusterIdentifier(TestDBIdentifier)
+
+	err := aurora.AddReaders(context.TODO(),
+		ReaderSpec{DBInstanceClass: "db.r5.xlarge", AvailabilityZone: "us-east-1b", PromotionTier: aws.Int32(0)},
+		ReaderSpec{DBInstanceIdentifier: "foo-analytics", PromotionTier: aws.Int32(15)},
+	)
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := aurora.WaitUntilAvailable(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	desc, err := svc.Instance().SetDBInstanceIdentifier("foo-analytics").Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if desc.DBInstanceClass != "db.r5.large" || desc.DBClusterIdentifier != TestDBIdentifier {
+		t.Fatalf("unexpected instance %#v\n", desc)
+	}
+
+	// Scaling down removes foo-analytics, which is promoted last.
+	if err := aurora.ScaleReaders(context.TODO(), 1); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := aurora.WaitUntilAvailable(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := aurora.ScaleReaders(context.TODO(), 3); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := aurora.WaitUntilAvailable(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	cdesc, err := aurora.Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	members := map[string]bool{}
+	for _, m := range cdesc.DBClusterMembers {
+		members[m.DBInstanceIdentifier] = m.IsClusterWrite
+	}
+	if len(members) != 4 || !members["foo-instance-0"] || !hasMembers(members, "foo-reader-1", "foo-reader-2", "foo-reader-3") {
+		t.Fatalf("unexpected members %#v\n", cdesc.DBClusterMembers)
+	}
+
+	if err := aurora.RemoveReaders(context.TODO(), "foo-instance-0"); err == nil {
+		t.Fatalf("expected writer removal error\n")
+	}
+	if err := aurora.RemoveReaders(context.TODO(), "foo-reader-2", "foo-reader-3"); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+}
+
+func hasMembers(members map[string]bool, ids ...string) bool {
+	for _, id := range ids {
+		if _, ok := members[id]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,469 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	dbmesh "github.com/database-mesh/golang-sdk/aws"
+)
+
+var ErrNoLagSource = errors.New("no replication lag source")
+
+// GlobalDatabase is an Aurora global database: a primary cluster taking the
+// writes in one region, replicated to read-only secondary clusters in other
+// regions. Every region it touches needs an API, e.g. a session.
+type GlobalDatabase interface {
+	SetGlobalClusterIdentifier(id string) GlobalDatabase
+	// SetPrimaryRegion sets the region of the primary cluster, where the
+	// global cluster is created and managed from.
+	SetPrimaryRegion(region string) GlobalDatabase
+	// SetSourceDBClusterIdentifier makes an existing cluster of the primary
+	// region the primary, by identifier or ARN.
+	SetSourceDBClusterIdentifier(id string) GlobalDatabase
+	SetEngine(engine string) GlobalDatabase
+	SetEngineVersion(version string) GlobalDatabase
+	SetDatabaseName(name string) GlobalDatabase
+	SetStorageEncrypted(enable bool) GlobalDatabase
+	SetDeletionProtection(enable bool) GlobalDatabase
+	SetLagSource(source LagSource) GlobalDatabase
+
+	Create(context.Context) error
+	Delete(context.Context) error
+	Describe(context.Context) (*DescGlobalCluster, error)
+	AddSecondary(context.Context, SecondarySpec) error
+	DetachSecondary(ctx context.Context, region, id string) error
+	Switchover(ctx context.Context, region, id string) error
+	ReplicationLag(context.Context) ([]RegionLag, error)
+
+	WaitUntilAvailable(context.Context, ...WaitOption) error
+}
+
+// SecondarySpec describes a secondary cluster. It takes the engine and
+// engine version of the global cluster, and gets an instance when
+// DBInstanceClass is set.
+type SecondarySpec struct {
+	Region              string
+	DBClusterIdentifier string
+	// DBInstanceIdentifier defaults to <DBClusterIdentifier>-instance-1.
+	DBInstanceIdentifier string
+	DBInstanceClass      string
+	DBSubnetGroupName    string
+	VpcSecurityGroupIds  []string
+	// KmsKeyId is required by encrypted global clusters since KMS keys are
+	// regional.
+	KmsKeyId                    string
+	EnableGlobalWriteForwarding bool
+}
+
+// LagSource reports how far a secondary cluster is behind the primary.
+type LagSource interface {
+	ReplicationLag(ctx context.Context, region, id string) (time.Duration, error)
+}
+
+type LagSourceFunc func(ctx context.Context, region, id string) (time.Duration, error)
+
+func (f LagSourceFunc) ReplicationLag(ctx context.Context, region, id string) (time.Duration, error) {
+	return f(ctx, region, id)
+}
+
+// RegionLag is the replication lag of the secondary cluster of a region.
+type RegionLag struct {
+	Region              string
+	DBClusterIdentifier string
+	Lag                 time.Duration
+}
+
+type DescGlobalCluster struct {
+	GlobalClusterIdentifier string
+	GlobalClusterArn        string
+	Engine                  string
+	EngineVersion           string
+	DatabaseName            string
+	Status                  string
+	DeletionProtection      bool
+	StorageEncrypted        bool
+	Members                 []GlobalMember
+	// FailoverState is set while a switchover is in progress.
+	FailoverState *GlobalFailoverState
+}
+
+type GlobalMember struct {
+	Region              string
+	DBClusterIdentifier string
+	DBClusterArn        string
+	IsWriter            bool
+	// Readers are the ARNs of the secondary clusters, set on the writer.
+	Readers                     []string
+	GlobalWriteForwardingStatus string
+}
+
+type GlobalFailoverState struct {
+	FromDBClusterArn string
+	ToDBClusterArn   string
+	Status           string
+}
+
+// Writer returns the primary cluster, nil if the global cluster has none.
+func (d *DescGlobalCluster) Writer() *GlobalMember {
+	for n := range d.Members {
+		if d.Members[n].IsWriter {
+			return &d.Members[n]
+		}
+	}
+	return nil
+}
+
+type rdsGlobalDatabase struct {
+	apis          map[string]API
+	primaryRegion string
+	lag           LagSource
+
+	createParam   *rds.CreateGlobalClusterInput
+	deleteParam   *rds.DeleteGlobalClusterInput
+	describeParam *rds.DescribeGlobalClustersInput
+}
+
+// NewGlobalDatabase returns a global database over the regions of sess,
+// whose replication lag comes from CloudWatch.
+func NewGlobalDatabase(sess dbmesh.Sessions) GlobalDatabase {
+	apis := map[string]API{}
+	for region, cfg := range sess {
+		apis[region] = rds.NewFromConfig(cfg)
+	}
+	return NewGlobalDatabaseWithAPI(apis).SetLagSource(NewCloudWatchLagSource(sess))
+}
+
+// NewGlobalDatabaseWithAPI returns a global database over the APIs of each
+// region, e.g. fakes. It has no LagSource unless SetLagSource is called.
+func NewGlobalDatabaseWithAPI(apis map[string]API) GlobalDatabase {
+	return &rdsGlobalDatabase{
+		apis:          apis,
+		createParam:   &rds.CreateGlobalClusterInput{},
+		deleteParam:   &rds.DeleteGlobalClusterInput{},
+		describeParam: &rds.DescribeGlobalClustersInput{},
+	}
+}
+
+func (s *rdsGlobalDatabase) SetGlobalClusterIdentifier(id string) GlobalDatabase {
+	s.createParam.GlobalClusterIdentifier = aws.String(id)
+	s.deleteParam.GlobalClusterIdentifier = aws.String(id)
+	s.describeParam.GlobalClusterIdentifier = aws.String(id)
+	return s
+}
+
+func (s *rdsGlobalDatabase) SetPrimaryRegion(region string) GlobalDatabase {
+	s.primaryRegion = region
+	return s
+}
+
+func (s *rdsGlobalDatabase) SetSourceDBClusterIdentifier(id string) GlobalDatabase {
+	s.createParam.SourceDBClusterIdentifier = aws.String(id)
+	return s
+}
+
+func (s *rdsGlobalDatabase) SetEngine(engine string) GlobalDatabase {
+	s.createParam.Engine = aws.String(engine)
+	return s
+}
+
+func (s *rdsGlobalDatabase) SetEngineVersion(version string) GlobalDatabase {
+	s.createParam.EngineVersion = aws.String(version)
+	return s
+}
+
+func (s *rdsGlobalDatabase) SetDatabaseName(name string) GlobalDatabase {
+	s.createParam.DatabaseName = aws.String(name)
+	return s
+}
+
+func (s *rdsGlobalDatabase) SetStorageEncrypted(enable bool) GlobalDatabase {
+	s.createParam.StorageEncrypted = aws.Bool(enable)
+	return s
+}
+
+func (s *rdsGlobalDatabase) SetDeletionProtection(enable bool) GlobalDatabase {
+	s.createParam.DeletionProtection = aws.Bool(enable)
+	return s
+}
+
+func (s *rdsGlobalDatabase) SetLagSource(source LagSource) GlobalDatabase {
+	s.lag = source
+	return s
+}
+
+func (s *rdsGlobalDatabase) api(region string) (API, error) {
+	core, ok := s.apis[region]
+	if !ok {
+		return nil, fmt.Errorf("region %s: %w", region, dbmesh.ErrSessionNotFound)
+	}
+	return core, nil
+}
+
+// globalAPI returns the API global cluster operations are sent to, the one
+// of the primary region or else of the first region.
+func (s *rdsGlobalDatabase) globalAPI() (API, error) {
+	if s.primaryRegion != "" {
+		return s.api(s.primaryRegion)
+	}
+	regions := make([]string, 0, len(s.apis))
+	for region := range s.apis {
+		regions = append(regions, region)
+	}
+	if len(regions) == 0 {
+		return nil, dbmesh.ErrSessionNotFound
+	}
+	sort.Strings(regions)
+	return s.apis[regions[0]], nil
+}
+
+// clusterArn returns the ARN of the cluster id of the region, id may already
+// be an ARN.
+func (s *rdsGlobalDatabase) clusterArn(ctx context.Context, region, id string) (string, error) {
+	if arn.IsARN(id) {
+		return id, nil
+	}
+	core, err := s.api(region)
+	if err != nil {
+		return "", err
+	}
+	desc, err := describeCluster(ctx, core, &rds.DescribeDBClustersInput{DBClusterIdentifier: aws.String(id)})
+	if err != nil {
+		return "", err
+	}
+	if desc.DBClusterArn == "" {
+		return "", fmt.Errorf("DB cluster %s not found in region %s", id, region)
+	}
+	return desc.DBClusterArn, nil
+}
+
+// Create creates the global cluster, from the source cluster if one is set.
+func (s *rdsGlobalDatabase) Create(ctx context.Context) error {
+	if s.primaryRegion == "" {
+		return errors.New("primary region is not set")
+	}
+	core, err := s.api(s.primaryRegion)
+	if err != nil {
+		return err
+	}
+	param := *s.createParam
+	if sid := aws.ToString(param.SourceDBClusterIdentifier); sid != "" {
+		sourceArn, err := s.clusterArn(ctx, s.primaryRegion, sid)
+		if err != nil {
+			return err
+		}
+		param.SourceDBClusterIdentifier = aws.String(sourceArn)
+	}
+	_, err = core.CreateGlobalCluster(ctx, &param)
+	return err
+}
+
+// Delete deletes the global cluster, which must have no member left, see
+// DetachSecondary.
+func (s *rdsGlobalDatabase) Delete(ctx context.Context) error {
+	core, err := s.globalAPI()
+	if err != nil {
+		return err
+	}
+	_, err = core.DeleteGlobalCluster(ctx, s.deleteParam)
+	return err
+}
+
+func (s *rdsGlobalDatabase) Describe(ctx context.Context) (*DescGlobalCluster, error) {
+	core, err := s.globalAPI()
+	if err != nil {
+		return nil, err
+	}
+	output, err := core.DescribeGlobalClusters(ctx, s.describeParam)
+	if err != nil {
+		return nil, err
+	}
+	if len(output.GlobalClusters) == 0 {
+		return nil, fmt.Errorf("global cluster %s not found", aws.ToString(s.describeParam.GlobalClusterIdentifier))
+	}
+	return convertGlobalCluster(output.GlobalClusters[0]), nil
+}
+
+func convertGlobalCluster(cluster types.GlobalCluster) *DescGlobalCluster {
+	desc := &DescGlobalCluster{
+		GlobalClusterIdentifier: aws.ToString(cluster.GlobalClusterIdentifier),
+		GlobalClusterArn:        aws.ToString(cluster.GlobalClusterArn),
+		Engine:                  aws.ToString(cluster.Engine),
+		EngineVersion:           aws.ToString(cluster.EngineVersion),
+		DatabaseName:            aws.ToString(cluster.DatabaseName),
+		Status:                  aws.ToString(cluster.Status),
+		DeletionProtection:      aws.ToBool(cluster.DeletionProtection),
+		StorageEncrypted:        aws.ToBool(cluster.StorageEncrypted),
+		Members:                 []GlobalMember{},
+	}
+	for _, m := range cluster.GlobalClusterMembers {
+		member := GlobalMember{
+			DBClusterArn:                aws.ToString(m.DBClusterArn),
+			IsWriter:                    m.IsWriter,
+			Readers:                     m.Readers,
+			GlobalWriteForwardingStatus: string(m.GlobalWriteForwardingStatus),
+		}
+		if a, err := arn.Parse(member.DBClusterArn); err == nil {
+			member.Region = a.Region
+			member.DBClusterIdentifier = a.Resource[strings.LastIndex(a.Resource, ":")+1:]
+		}
+		desc.Members = append(desc.Members, member)
+	}
+	if state := cluster.FailoverState; state != nil {
+		desc.FailoverState = &GlobalFailoverState{
+			FromDBClusterArn: aws.ToString(state.FromDbClusterArn),
+			ToDBClusterArn:   aws.ToString(state.ToDbClusterArn),
+			Status:           string(state.Status),
+		}
+	}
+	return desc
+}
+
+// AddSecondary creates a secondary cluster in the region of the spec.
+func (s *rdsGlobalDatabase) AddSecondary(ctx context.Context, spec SecondarySpec) error {
+	if spec.DBClusterIdentifier == "" {
+		return errors.New("secondary cluster identifier is not set")
+	}
+	core, err := s.api(spec.Region)
+	if err != nil {
+		return err
+	}
+	desc, err := s.Describe(ctx)
+	if err != nil {
+		return err
+	}
+
+	param := &rds.CreateDBClusterInput{
+		DBClusterIdentifier:         aws.String(spec.DBClusterIdentifier),
+		GlobalClusterIdentifier:     aws.String(desc.GlobalClusterIdentifier),
+		Engine:                      aws.String(desc.Engine),
+		EngineVersion:               aws.String(desc.EngineVersion),
+		VpcSecurityGroupIds:         spec.VpcSecurityGroupIds,
+		EnableGlobalWriteForwarding: aws.Bool(spec.EnableGlobalWriteForwarding),
+	}
+	if spec.DBSubnetGroupName != "" {
+		param.DBSubnetGroupName = aws.String(spec.DBSubnetGroupName)
+	}
+	if spec.KmsKeyId != "" {
+		param.KmsKeyId = aws.String(spec.KmsKeyId)
+	}
+	if _, err := core.CreateDBCluster(ctx, param); err != nil {
+		return err
+	}
+	if spec.DBInstanceClass == "" {
+		return nil
+	}
+
+	id := spec.DBInstanceIdentifier
+	if id == "" {
+		id = fmt.Sprintf("%s-instance-1", spec.DBClusterIdentifier)
+	}
+	_, err = core.CreateDBInstance(ctx, &rds.CreateDBInstanceInput{
+		DBInstanceIdentifier: aws.String(id),
+		DBClusterIdentifier:  aws.String(spec.DBClusterIdentifier),
+		DBInstanceClass:      aws.String(spec.DBInstanceClass),
+		Engine:               aws.String(desc.Engine),
+	})
+	return err
+}
+
+// DetachSecondary removes the cluster of the region from the global cluster,
+// which promotes it to a standalone cluster taking writes. It is also the
+// way out when the primary region is down, at the cost of the data not
+// replicated yet.
+// NOTE: The primary can only be detached once it has no secondary left.
+func (s *rdsGlobalDatabase) DetachSecondary(ctx context.Context, region, id string) error {
+	clusterArn, err := s.clusterArn(ctx, region, id)
+	if err != nil {
+		return err
+	}
+	core, err := s.api(region)
+	if err != nil {
+		return err
+	}
+	_, err = core.RemoveFromGlobalCluster(ctx, &rds.RemoveFromGlobalClusterInput{
+		GlobalClusterIdentifier: s.describeParam.GlobalClusterIdentifier,
+		DbClusterIdentifier:     aws.String(clusterArn),
+	})
+	return err
+}
+
+// Switchover makes the secondary cluster of the region the primary, after it
+// caught up with the current primary so no data is lost. The former primary
+// becomes a secondary. Call SetPrimaryRegion afterwards to manage the global
+// cluster from the new primary region.
+func (s *rdsGlobalDatabase) Switchover(ctx context.Context, region, id string) error {
+	clusterArn, err := s.clusterArn(ctx, region, id)
+	if err != nil {
+		return err
+	}
+	core, err := s.globalAPI()
+	if err != nil {
+		return err
+	}
+	_, err = core.FailoverGlobalCluster(ctx, &rds.FailoverGlobalClusterInput{
+		GlobalClusterIdentifier:   s.describeParam.GlobalClusterIdentifier,
+		TargetDbClusterIdentifier: aws.String(clusterArn),
+	})
+	return err
+}
+
+// ReplicationLag returns the lag of every secondary cluster, ordered by
+// region.
+func (s *rdsGlobalDatabase) ReplicationLag(ctx context.Context) ([]RegionLag, error) {
+	if s.lag == nil {
+		return nil, ErrNoLagSource
+	}
+	desc, err := s.Describe(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lags := []RegionLag{}
+	for _, m := range desc.Members {
+		if m.IsWriter {
+			continue
+		}
+		lag, err := s.lag.ReplicationLag(ctx, m.Region, m.DBClusterIdentifier)
+		if err != nil {
+			return nil, fmt.Errorf("replication lag of %s in region %s: %w", m.DBClusterIdentifier, m.Region, err)
+		}
+		lags = append(lags, RegionLag{Region: m.Region, DBClusterIdentifier: m.DBClusterIdentifier, Lag: lag})
+	}
+	sort.Slice(lags, func(i, j int) bool {
+		return lags[i].Region < lags[j].Region
+	})
+	return lags, nil
+}
+
+// WaitUntilAvailable waits for the global cluster to be available, e.g.
+// after Create or Switchover.
+func (s *rdsGlobalDatabase) WaitUntilAvailable(ctx context.Context, opts ...WaitOption) error {
+	return wait(ctx, func(ctx context.Context) (string, bool, error) {
+		desc, err := s.Describe(ctx)
+		if err != nil {
+			return "", false, err
+		}
+		return desc.Status, desc.Status == StatusAvailable && desc.FailoverState == nil, nil
+	}, opts...)
+}
@@ -0,0 +1,160 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	dbmesh "github.com/database-mesh/golang-sdk/aws"
+	"github.com/database-mesh/golang-sdk/aws/client/rds/fake"
+)
+
+func Test_GlobalDatabase(t *testing.T) {
+	east := fake.New().SetRegion("us-east-1").SetAutoAdvance(true)
+	west := fake.New().SetRegion("us-west-2").SetAutoAdvance(true)
+	fake.Connect(east, west)
+	newAuroraWithReaders(t, east)
+
+	lags := map[string]time.Duration{"us-west-2": 80 * time.Millisecond}
+	global := NewGlobalDatabaseWithAPI(map[string]API{"us-east-1": east, "us-west-2": west}).
+		SetGlobalClusterIdentifier("foo-global").
+		SetPrimaryRegion("us-east-1").
+		SetSourceDBClusterIdentifier(TestDBIdentifier).
+		SetLagSource(LagSourceFunc(func(_ context.Context, region, _ string) (time.Duration, error) {
+			return lags[region], nil
+		}))
+	if err := global.Create(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := global.WaitUntilAvailable(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	err := global.AddSecondary(context.TODO(), SecondarySpec{
+		Region:              "us-west-2",
+		DBClusterIdentifier: "foo-west",
+		DBInstanceClass:     "db.r5.large",
+	})
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	secondary := NewServiceWithAPI(west).Aurora().SetDBClusterIdentifier("foo-west")
+	if err := secondary.WaitUntilAvailable(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+
+	desc, err := global.Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(desc.Members) != 2 || desc.Writer() == nil || desc.Writer().Region != "us-east-1" || desc.Engine != "aurora-mysql" {
+		t.Fatalf("unexpected global cluster %#v\n", desc)
+	}
+	lag, err := global.ReplicationLag(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(lag) != 1 || lag[0] != (RegionLag{Region: "us-west-2", DBClusterIdentifier: "foo-west", Lag: 80 * time.Millisecond}) {
+		t.Fatalf("unexpected lag %#v\n", lag)
+	}
+
+	// Members cannot be deleted before they are detached.
+	if err := NewServiceWithAPI(west).Cluster().SetDBClusterIdentifier("foo-west").SetSkipFinalSnapshot(true).Delete(context.TODO()); err == nil {
+		t.Fatalf("expected global member deletion error\n")
+	}
+
+	if err := global.Switchover(context.TODO(), "us-west-2", "foo-west"); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := global.WaitUntilAvailable(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	desc, err = global.Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if w := desc.Writer(); w == nil || w.Region != "us-west-2" || w.DBClusterIdentifier != "foo-west" || len(w.Readers) != 1 {
+		t.Fatalf("unexpected writer %#v\n", w)
+	}
+
+	// The primary goes last.
+	if err := global.DetachSecondary(context.TODO(), "us-west-2", "foo-west"); err == nil {
+		t.Fatalf("expected primary detach error\n")
+	}
+	if err := global.DetachSecondary(context.TODO(), "us-east-1", TestDBIdentifier); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := global.DetachSecondary(context.TODO(), "us-west-2", "foo-west"); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := global.Delete(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+}
+
+const getMetricStatisticsResponseBody = `<GetMetricStatisticsResponse xmlns="http://monitoring.amazonaws.com/doc/2010-08-01/">
+  <GetMetricStatisticsResult>
+    <Datapoints>
+      <member>
+        <Timestamp>2023-01-01T00:00:00Z</Timestamp>
+        <Average>900</Average>
+      </member>
+      <member>
+        <Timestamp>2023-01-01T00:01:00Z</Timestamp>
+        <Average>120.5</Average>
+      </member>
+    </Datapoints>
+    <Label>AuroraGlobalDBReplicationLag</Label>
+  </GetMetricStatisticsResult>
+</GetMetricStatisticsResponse>`
+
+func Test_CloudWatchLagSource(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("%+v\n", err)
+		}
+		if r.PostForm.Get("Action") != "GetMetricStatistics" || r.PostForm.Get("MetricName") != replicationLagMetric || r.PostForm.Get("Dimensions.member.1.Value") != "foo-west" {
+			t.Errorf("unexpected request %+v\n", r.PostForm)
+		}
+		if !strings.Contains(r.Header.Get("Authorization"), "Credential="+TestAWSAccessKey+"/") {
+			t.Errorf("unexpected authorization %s\n", r.Header.Get("Authorization"))
+		}
+		_, _ = w.Write([]byte(getMetricStatisticsResponseBody))
+	}))
+	defer srv.Close()
+
+	sess, err := dbmesh.NewSessions().
+		SetCredential(TestAWSRegion, TestAWSAccessKey, TestAWSSecretAccessKey).
+		SetEndpoint(srv.URL).
+		BuildStrict()
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	lag, err := NewCloudWatchLagSource(sess).ReplicationLag(context.TODO(), TestAWSRegion, "foo-west")
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if lag != 120500*time.Microsecond {
+		t.Fatalf("unexpected lag %s\n", lag)
+	}
+	if _, err := NewCloudWatchLagSource(sess).ReplicationLag(context.TODO(), "us-west-2", "foo-west"); !errors.Is(err, dbmesh.ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound, got %+v\n", err)
+	}
+}
@@ -16,10 +16,12 @@ package rds
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
 )
 
 type Instance interface {
@@ -49,21 +51,69 @@ type Instance interface {
 	SetDBClusterIdentifier(id string) Instance
 	SetPublicAccessible(enable bool) Instance
 	SetLicenseModel(model string) Instance
+	SetMaxAllocatedStorage(size int32) Instance
+	SetStorageType(t string) Instance
+	SetBackupRetentionPeriod(days int32) Instance
+	SetDBParameterGroupName(name string) Instance
+	SetTags(tags map[string]string) Instance
+	SetDeletionProtection(enable bool) Instance
+	SetAllowMajorVersionUpgrade(enable bool) Instance
+	SetApplyImmediately(enable bool) Instance
+	SetSourceRegion(region string) Instance
+	SetKmsKeyId(id string) Instance
+	SetStopDBSnapshotIdentifier(id string) Instance
 
 	Create(context.Context) error
 	Delete(context.Context) error
 	Reboot(context.Context) error
 	Describe(context.Context) (*DescInstance, error)
 	RestorePitr(context.Context) error
+	Modify(context.Context) error
+	List(context.Context, *ListFilter) ([]*DescInstance, error)
+	CreateReadReplica(context.Context) error
+	PromoteReadReplica(context.Context) error
+	ReplicaTopology(context.Context) (*ReplicaTopology, error)
+	Stop(context.Context) error
+	Start(context.Context) error
+	Events(context.Context, *EventFilter) ([]*Event, error)
+	ListLogFiles(context.Context) ([]*LogFile, error)
+	DownloadLogFile(ctx context.Context, name string) (io.Reader, error)
+	TailLogFile(ctx context.Context, name string, lines int32) (io.Reader, error)
+
+	WaitUntilAvailable(context.Context, ...WaitOption) error
+	WaitUntilDeleted(context.Context, ...WaitOption) error
+	WaitUntilModified(context.Context, ...WaitOption) error
+	WaitUntilStopped(context.Context, ...WaitOption) error
 }
 
 type rdsInstance struct {
-	core                     *rds.Client
+	core                     API
 	createInstanceParam      *rds.CreateDBInstanceInput
 	deleteInstanceParam      *rds.DeleteDBInstanceInput
 	rebootInstanceParam      *rds.RebootDBInstanceInput
 	describeInstanceParam    *rds.DescribeDBInstancesInput
 	restoreInstancePitrParam *rds.RestoreDBInstanceToPointInTimeInput
+	modifyInstanceParam      *rds.ModifyDBInstanceInput
+	createReplicaParam       *rds.CreateDBInstanceReadReplicaInput
+	promoteReplicaParam      *rds.PromoteReadReplicaInput
+	stopInstanceParam        *rds.StopDBInstanceInput
+	startInstanceParam       *rds.StartDBInstanceInput
+}
+
+func newInstance(core API) *rdsInstance {
+	return &rdsInstance{
+		core:                     core,
+		createInstanceParam:      &rds.CreateDBInstanceInput{},
+		deleteInstanceParam:      &rds.DeleteDBInstanceInput{},
+		rebootInstanceParam:      &rds.RebootDBInstanceInput{},
+		describeInstanceParam:    &rds.DescribeDBInstancesInput{},
+		restoreInstancePitrParam: &rds.RestoreDBInstanceToPointInTimeInput{},
+		modifyInstanceParam:      &rds.ModifyDBInstanceInput{},
+		createReplicaParam:       &rds.CreateDBInstanceReadReplicaInput{},
+		promoteReplicaParam:      &rds.PromoteReadReplicaInput{},
+		stopInstanceParam:        &rds.StopDBInstanceInput{},
+		startInstanceParam:       &rds.StartDBInstanceInput{},
+	}
 }
 
 // CreateDBInstanceInput
@@ -74,6 +124,7 @@ func (s *rdsInstance) SetEngine(engine string) Instance {
 
 func (s *rdsInstance) SetEngineVersion(version string) Instance {
 	s.createInstanceParam.EngineVersion = aws.String(version)
+	s.modifyInstanceParam.EngineVersion = aws.String(version)
 	return s
 }
 
@@ -82,6 +133,11 @@ func (s *rdsInstance) SetDBInstanceIdentifier(id string) Instance {
 	s.deleteInstanceParam.DBInstanceIdentifier = aws.String(id)
 	s.rebootInstanceParam.DBInstanceIdentifier = aws.String(id)
 	s.describeInstanceParam.DBInstanceIdentifier = aws.String(id)
+	s.modifyInstanceParam.DBInstanceIdentifier = aws.String(id)
+	s.createReplicaParam.DBInstanceIdentifier = aws.String(id)
+	s.promoteReplicaParam.DBInstanceIdentifier = aws.String(id)
+	s.stopInstanceParam.DBInstanceIdentifier = aws.String(id)
+	s.startInstanceParam.DBInstanceIdentifier = aws.String(id)
 	return s
 }
 
@@ -98,11 +154,14 @@ func (s *rdsInstance) SetMasterUserPassword(pass string) Instance {
 func (s *rdsInstance) SetDBInstanceClass(class string) Instance {
 	s.createInstanceParam.DBInstanceClass = aws.String(class)
 	s.restoreInstancePitrParam.DBInstanceClass = aws.String(class)
+	s.modifyInstanceParam.DBInstanceClass = aws.String(class)
+	s.createReplicaParam.DBInstanceClass = aws.String(class)
 	return s
 }
 
 func (s *rdsInstance) SetAllocatedStorage(size int32) Instance {
 	s.createInstanceParam.AllocatedStorage = aws.Int32(size)
+	s.modifyInstanceParam.AllocatedStorage = aws.Int32(size)
 	// s.restoreInstancePitrParam.MaxAllocatedStorage = aws.Int32(size)
 	return s
 }
@@ -110,6 +169,8 @@ func (s *rdsInstance) SetAllocatedStorage(size int32) Instance {
 func (s *rdsInstance) SetIOPS(iops int32) Instance {
 	s.createInstanceParam.Iops = aws.Int32(iops)
 	s.restoreInstancePitrParam.Iops = aws.Int32(iops)
+	s.modifyInstanceParam.Iops = aws.Int32(iops)
+	s.createReplicaParam.Iops = aws.Int32(iops)
 	return s
 }
 
@@ -122,24 +183,29 @@ func (s *rdsInstance) SetDBName(name string) Instance {
 func (s *rdsInstance) SetVpcSecurityGroupIds(sgs []string) Instance {
 	s.createInstanceParam.VpcSecurityGroupIds = sgs
 	s.restoreInstancePitrParam.VpcSecurityGroupIds = sgs
+	s.createReplicaParam.VpcSecurityGroupIds = sgs
 	return s
 }
 
 func (s *rdsInstance) SetDBSubnetGroup(name string) Instance {
 	s.createInstanceParam.DBSubnetGroupName = aws.String(name)
 	s.restoreInstancePitrParam.DBSubnetGroupName = aws.String(name)
+	s.createReplicaParam.DBSubnetGroupName = aws.String(name)
 	return s
 }
 
 func (s *rdsInstance) SetMultiAZ(enable bool) Instance {
 	s.createInstanceParam.MultiAZ = aws.Bool(enable)
 	s.restoreInstancePitrParam.MultiAZ = aws.Bool(enable)
+	s.modifyInstanceParam.MultiAZ = aws.Bool(enable)
+	s.createReplicaParam.MultiAZ = aws.Bool(enable)
 	return s
 }
 
 func (s *rdsInstance) SetAvailabilityZones(az string) Instance {
 	s.createInstanceParam.AvailabilityZone = aws.String(az)
 	s.restoreInstancePitrParam.AvailabilityZone = aws.String(az)
+	s.createReplicaParam.AvailabilityZone = aws.String(az)
 	return s
 }
 
@@ -155,7 +221,7 @@ func (s *rdsInstance) SetDeleteAutomateBackups(enable bool) Instance {
 }
 
 func (s *rdsInstance) SetFinalDBSnapshotIdentifier(id string) Instance {
-	s.deleteInstanceParam.DBInstanceIdentifier = aws.String(id)
+	s.deleteInstanceParam.FinalDBSnapshotIdentifier = aws.String(id)
 	return s
 }
 
@@ -209,6 +275,7 @@ func (s *rdsInstance) SetSourceDBInstanceAutomatedBackupsArn(arn string) Instanc
 
 func (s *rdsInstance) SetSourceDBInstanceIdentifier(sid string) Instance {
 	s.restoreInstancePitrParam.SourceDBInstanceIdentifier = aws.String(sid)
+	s.createReplicaParam.SourceDBInstanceIdentifier = aws.String(sid)
 	return s
 }
 
@@ -229,6 +296,7 @@ func (s *rdsInstance) SetDBClusterIdentifier(id string) Instance {
 
 func (s *rdsInstance) SetPublicAccessible(enable bool) Instance {
 	s.createInstanceParam.PubliclyAccessible = aws.Bool(enable)
+	s.createReplicaParam.PubliclyAccessible = aws.Bool(enable)
 	return s
 }
 
@@ -242,6 +310,74 @@ func (s *rdsInstance) RestorePitr(ctx context.Context) error {
 	return err
 }
 
+// ModifyDBInstanceInput
+func (s *rdsInstance) SetMaxAllocatedStorage(size int32) Instance {
+	s.createInstanceParam.MaxAllocatedStorage = aws.Int32(size)
+	s.modifyInstanceParam.MaxAllocatedStorage = aws.Int32(size)
+	s.createReplicaParam.MaxAllocatedStorage = aws.Int32(size)
+	return s
+}
+
+func (s *rdsInstance) SetStorageType(t string) Instance {
+	s.createInstanceParam.StorageType = aws.String(t)
+	s.restoreInstancePitrParam.StorageType = aws.String(t)
+	s.modifyInstanceParam.StorageType = aws.String(t)
+	s.createReplicaParam.StorageType = aws.String(t)
+	return s
+}
+
+func (s *rdsInstance) SetBackupRetentionPeriod(days int32) Instance {
+	s.createInstanceParam.BackupRetentionPeriod = aws.Int32(days)
+	s.modifyInstanceParam.BackupRetentionPeriod = aws.Int32(days)
+	s.promoteReplicaParam.BackupRetentionPeriod = aws.Int32(days)
+	return s
+}
+
+// SetTags sets the tags of the instance created by Create, RestorePitr or
+// CreateReadReplica. Tags set several times are merged.
+func (s *rdsInstance) SetTags(tags map[string]string) Instance {
+	s.createInstanceParam.Tags = mergeTags(s.createInstanceParam.Tags, tags)
+	s.restoreInstancePitrParam.Tags = mergeTags(s.restoreInstancePitrParam.Tags, tags)
+	s.createReplicaParam.Tags = mergeTags(s.createReplicaParam.Tags, tags)
+	return s
+}
+
+func (s *rdsInstance) SetDBParameterGroupName(name string) Instance {
+	s.createInstanceParam.DBParameterGroupName = aws.String(name)
+	s.restoreInstancePitrParam.DBParameterGroupName = aws.String(name)
+	s.modifyInstanceParam.DBParameterGroupName = aws.String(name)
+	s.createReplicaParam.DBParameterGroupName = aws.String(name)
+	return s
+}
+
+func (s *rdsInstance) SetDeletionProtection(enable bool) Instance {
+	s.createInstanceParam.DeletionProtection = aws.Bool(enable)
+	s.restoreInstancePitrParam.DeletionProtection = aws.Bool(enable)
+	s.modifyInstanceParam.DeletionProtection = aws.Bool(enable)
+	s.createReplicaParam.DeletionProtection = aws.Bool(enable)
+	return s
+}
+
+// NOTE: Required by Modify to move to a new major engine version.
+func (s *rdsInstance) SetAllowMajorVersionUpgrade(enable bool) Instance {
+	s.modifyInstanceParam.AllowMajorVersionUpgrade = enable
+	return s
+}
+
+// SetApplyImmediately applies Modify right away instead of during the next
+// maintenance window.
+func (s *rdsInstance) SetApplyImmediately(enable bool) Instance {
+	s.modifyInstanceParam.ApplyImmediately = enable
+	return s
+}
+
+// Modify sends every modifiable field set on the builder, e.g. by
+// SetDBInstanceClass or SetAllocatedStorage.
+func (s *rdsInstance) Modify(ctx context.Context) error {
+	_, err := s.core.ModifyDBInstance(ctx, s.modifyInstanceParam)
+	return err
+}
+
 type ReadReplicaStatus struct {
 	Message    string
 	Normal     bool
@@ -269,6 +405,16 @@ type DescInstance struct {
 	DBParameterGroups                     []ParameterGroupStatus
 	DBClusterIdentifier                   string
 	ReadReplicaDBClusterIdentifiers       []string
+	Engine                                string
+	EngineVersion                         string
+	DBInstanceClass                       string
+	Tags                                  map[string]string
+	// PendingModifications reports changes waiting to be applied, e.g. in
+	// the next maintenance window.
+	PendingModifications bool
+	// AutomaticRestartTime is when RDS starts the stopped instance again,
+	// see AutoRestartAfter.
+	AutomaticRestartTime time.Time
 }
 
 type ParameterGroupStatus struct {
@@ -283,45 +429,84 @@ func (s *rdsInstance) Describe(ctx context.Context) (*DescInstance, error) {
 	}
 	desc := &DescInstance{}
 	if len(output.DBInstances) > 0 {
-		desc.CharSetName = aws.ToString(output.DBInstances[0].CharacterSetName)
-		desc.DBInstanceArn = aws.ToString(output.DBInstances[0].DBInstanceArn)
-		desc.DBInstanceIdentifier = aws.ToString(output.DBInstances[0].DBInstanceIdentifier)
-		desc.DeletionProtection = output.DBInstances[0].DeletionProtection
-		desc.InstanceCreateTime = aws.ToTime(output.DBInstances[0].InstanceCreateTime)
-		desc.Timezone = aws.ToString(output.DBInstances[0].Timezone)
-		desc.SecondaryAZ = aws.ToString(output.DBInstances[0].SecondaryAvailabilityZone)
-		desc.ReadReplicaSourceDBInstanceIdentifier = aws.ToString(output.DBInstances[0].ReadReplicaSourceDBInstanceIdentifier)
-		desc.ReadReplicaDBInstanceIdentifiers = output.DBInstances[0].ReadReplicaDBInstanceIdentifiers
-
-		for _, s := range output.DBInstances[0].StatusInfos {
-			desc.ReadReplicaStatusInfos = append(desc.ReadReplicaStatusInfos, ReadReplicaStatus{
-				Message:    aws.ToString(s.Message),
-				Normal:     s.Normal,
-				Status:     aws.ToString(s.Status),
-				StatusType: aws.ToString(s.StatusType),
-			})
-		}
+		desc = convertDBInstance(output.DBInstances[0])
+	}
+	return desc, nil
+}
 
-		if output.DBInstances[0].DBInstanceStatus != nil {
-			desc.DBInstanceStatus = aws.ToString(output.DBInstances[0].DBInstanceStatus)
-		}
+func convertDBInstance(instance types.DBInstance) *DescInstance {
+	desc := &DescInstance{}
+	desc.CharSetName = aws.ToString(instance.CharacterSetName)
+	desc.DBInstanceArn = aws.ToString(instance.DBInstanceArn)
+	desc.DBInstanceIdentifier = aws.ToString(instance.DBInstanceIdentifier)
+	desc.DeletionProtection = instance.DeletionProtection
+	desc.InstanceCreateTime = aws.ToTime(instance.InstanceCreateTime)
+	desc.Timezone = aws.ToString(instance.Timezone)
+	desc.SecondaryAZ = aws.ToString(instance.SecondaryAvailabilityZone)
+	desc.ReadReplicaSourceDBInstanceIdentifier = aws.ToString(instance.ReadReplicaSourceDBInstanceIdentifier)
+	desc.ReadReplicaDBInstanceIdentifiers = instance.ReadReplicaDBInstanceIdentifiers
+
+	for _, s := range instance.StatusInfos {
+		desc.ReadReplicaStatusInfos = append(desc.ReadReplicaStatusInfos, ReadReplicaStatus{
+			Message:    aws.ToString(s.Message),
+			Normal:     s.Normal,
+			Status:     aws.ToString(s.Status),
+			StatusType: aws.ToString(s.StatusType),
+		})
+	}
 
-		if output.DBInstances[0].Endpoint != nil {
-			desc.Endpoint = Endpoint{
-				Address: aws.ToString(output.DBInstances[0].Endpoint.Address),
-				Port:    aws.ToInt32(&output.DBInstances[0].Endpoint.Port),
-			}
-		}
+	if instance.DBInstanceStatus != nil {
+		desc.DBInstanceStatus = aws.ToString(instance.DBInstanceStatus)
+	}
 
-		for _, g := range output.DBInstances[0].DBParameterGroups {
-			desc.DBParameterGroups = append(desc.DBParameterGroups, ParameterGroupStatus{
-				Name:        aws.ToString(g.DBParameterGroupName),
-				ApplyStatus: aws.ToString(g.ParameterApplyStatus),
-			})
+	if instance.Endpoint != nil {
+		desc.Endpoint = Endpoint{
+			Address: aws.ToString(instance.Endpoint.Address),
+			Port:    aws.ToInt32(&instance.Endpoint.Port),
 		}
+	}
 
-		desc.ReadReplicaDBClusterIdentifiers = output.DBInstances[0].ReadReplicaDBClusterIdentifiers
-		desc.DBClusterIdentifier = aws.ToString(output.DBInstances[0].DBClusterIdentifier)
+	for _, g := range instance.DBParameterGroups {
+		desc.DBParameterGroups = append(desc.DBParameterGroups, ParameterGroupStatus{
+			Name:        aws.ToString(g.DBParameterGroupName),
+			ApplyStatus: aws.ToString(g.ParameterApplyStatus),
+		})
 	}
-	return desc, nil
+
+	desc.ReadReplicaDBClusterIdentifiers = instance.ReadReplicaDBClusterIdentifiers
+	desc.DBClusterIdentifier = aws.ToString(instance.DBClusterIdentifier)
+	desc.Engine = aws.ToString(instance.Engine)
+	desc.EngineVersion = aws.ToString(instance.EngineVersion)
+	desc.DBInstanceClass = aws.ToString(instance.DBInstanceClass)
+	desc.Tags = convertTags(instance.TagList)
+	desc.PendingModifications = hasPendingModifiedValues(instance.PendingModifiedValues)
+	desc.AutomaticRestartTime = aws.ToTime(instance.AutomaticRestartTime)
+	return desc
+}
+
+func hasPendingModifiedValues(v *types.PendingModifiedValues) bool {
+	if v == nil {
+		return false
+	}
+	return v.AllocatedStorage != nil ||
+		v.BackupRetentionPeriod != nil ||
+		v.CACertificateIdentifier != nil ||
+		v.DBInstanceClass != nil ||
+		v.DBInstanceIdentifier != nil ||
+		v.DBSubnetGroupName != nil ||
+		v.EngineVersion != nil ||
+		v.IAMDatabaseAuthenticationEnabled != nil ||
+		v.Iops != nil ||
+		v.LicenseModel != nil ||
+		v.MasterUserPassword != nil ||
+		v.MultiAZ != nil ||
+		hasPendingCloudwatchLogsExports(v.PendingCloudwatchLogsExports) ||
+		v.Port != nil ||
+		len(v.ProcessorFeatures) > 0 ||
+		v.StorageThroughput != nil ||
+		v.StorageType != nil
+}
+
+func hasPendingCloudwatchLogsExports(v *types.PendingCloudwatchLogsExports) bool {
+	return v != nil && (len(v.LogTypesToEnable) > 0 || len(v.LogTypesToDisable) > 0)
 }
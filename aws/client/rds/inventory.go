@@ -0,0 +1,252 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	dbmesh "github.com/database-mesh/golang-sdk/aws"
+)
+
+// DefaultInventoryParallelism is the number of sessions listed at the same
+// time unless WithParallelism says otherwise.
+const DefaultInventoryParallelism = 4
+
+type InventoryKind string
+
+const (
+	InventoryKindInstance       InventoryKind = "instance"
+	InventoryKindMultiAZCluster InventoryKind = "multi-az-cluster"
+	InventoryKindAuroraCluster  InventoryKind = "aurora-cluster"
+)
+
+// InventoryItem is a database found by ListInventory, whatever its kind.
+type InventoryItem struct {
+	Account       string
+	Region        string
+	Kind          InventoryKind
+	Identifier    string
+	Arn           string
+	Engine        string
+	EngineVersion string
+	Status        string
+	Endpoint      Endpoint
+	// ReaderEndpoint is the reader endpoint of clusters.
+	ReaderEndpoint string
+	// Members are the instances of clusters.
+	Members []string
+	Tags    map[string]string
+
+	// Instance is set for InventoryKindInstance, Cluster otherwise.
+	Instance *DescInstance
+	Cluster  *DescCluster
+}
+
+// InventoryError is the failure to list the databases of one session.
+type InventoryError struct {
+	Account string
+	Region  string
+	Err     error
+}
+
+func (e *InventoryError) Error() string {
+	return fmt.Sprintf("inventory of account %q region %s: %v", e.Account, e.Region, e.Err)
+}
+
+func (e *InventoryError) Unwrap() error {
+	return e.Err
+}
+
+// Inventory holds the databases of every session which could be listed and
+// the errors of the others.
+type Inventory struct {
+	Items  []InventoryItem
+	Errors []*InventoryError
+}
+
+type inventoryOptions struct {
+	parallelism int
+	filter      *ListFilter
+}
+
+type InventoryOption func(*inventoryOptions)
+
+// WithParallelism sets how many sessions are listed at the same time.
+func WithParallelism(n int) InventoryOption {
+	return func(o *inventoryOptions) {
+		o.parallelism = n
+	}
+}
+
+// WithListFilter applies the filter to every List call of the inventory.
+func WithListFilter(filter *ListFilter) InventoryOption {
+	return func(o *inventoryOptions) {
+		o.filter = filter
+	}
+}
+
+type inventoryTarget struct {
+	account string
+	region  string
+	core    API
+}
+
+// ListInventory lists the instances and clusters of every region of sess.
+func ListInventory(ctx context.Context, sess dbmesh.Sessions, opts ...InventoryOption) *Inventory {
+	targets := []inventoryTarget{}
+	for region, cfg := range sess {
+		targets = append(targets, inventoryTarget{account: dbmesh.DefaultAccount, region: region, core: rds.NewFromConfig(cfg)})
+	}
+	return listInventory(ctx, targets, opts...)
+}
+
+// ListRegistryInventory lists the instances and clusters of every account
+// and region of reg.
+func ListRegistryInventory(ctx context.Context, reg dbmesh.Registry, opts ...InventoryOption) *Inventory {
+	targets := []inventoryTarget{}
+	for key, cfg := range reg {
+		targets = append(targets, inventoryTarget{account: key.Account, region: key.Region, core: rds.NewFromConfig(cfg)})
+	}
+	return listInventory(ctx, targets, opts...)
+}
+
+func listInventory(ctx context.Context, targets []inventoryTarget, opts ...InventoryOption) *Inventory {
+	o := &inventoryOptions{parallelism: DefaultInventoryParallelism}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.parallelism < 1 {
+		o.parallelism = 1
+	}
+
+	inv := &Inventory{}
+	mu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	sem := make(chan struct{}, o.parallelism)
+	for _, t := range targets {
+		wg.Add(1)
+		go func(t inventoryTarget) {
+			defer wg.Done()
+
+			var (
+				items []InventoryItem
+				err   error
+			)
+			select {
+			case sem <- struct{}{}:
+				// Both cases may be ready once the context ended.
+				if err = ctx.Err(); err == nil {
+					items, err = t.list(ctx, o.filter)
+				}
+				<-sem
+			case <-ctx.Done():
+				// Regions still queued when the context ends are not listed.
+				err = ctx.Err()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				inv.Errors = append(inv.Errors, &InventoryError{Account: t.account, Region: t.region, Err: err})
+				return
+			}
+			inv.Items = append(inv.Items, items...)
+		}(t)
+	}
+	wg.Wait()
+
+	sort.Slice(inv.Items, func(i, j int) bool {
+		a, b := inv.Items[i], inv.Items[j]
+		if a.Account != b.Account {
+			return a.Account < b.Account
+		}
+		if a.Region != b.Region {
+			return a.Region < b.Region
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Identifier < b.Identifier
+	})
+	sort.Slice(inv.Errors, func(i, j int) bool {
+		if inv.Errors[i].Account != inv.Errors[j].Account {
+			return inv.Errors[i].Account < inv.Errors[j].Account
+		}
+		return inv.Errors[i].Region < inv.Errors[j].Region
+	})
+	return inv
+}
+
+// list returns the clusters and the instances which are not part of a
+// cluster, the latter being reported as cluster members.
+func (t inventoryTarget) list(ctx context.Context, filter *ListFilter) ([]InventoryItem, error) {
+	clusters, err := listClusters(ctx, t.core, filter)
+	if err != nil {
+		return nil, err
+	}
+	instances, err := newInstance(t.core).List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []InventoryItem{}
+	for _, c := range clusters {
+		item := InventoryItem{
+			Account:        t.account,
+			Region:         t.region,
+			Kind:           InventoryKindMultiAZCluster,
+			Identifier:     c.DBClusterIdentifier,
+			Arn:            c.DBClusterArn,
+			Engine:         c.Engine,
+			EngineVersion:  c.EngineVersion,
+			Status:         c.Status,
+			Endpoint:       Endpoint{Address: c.PrimaryEndpoint, Port: c.Port},
+			ReaderEndpoint: c.ReaderEndpoint,
+			Tags:           c.Tags,
+			Cluster:        c,
+		}
+		if strings.HasPrefix(c.Engine, "aurora") {
+			item.Kind = InventoryKindAuroraCluster
+		}
+		for _, m := range c.DBClusterMembers {
+			item.Members = append(item.Members, m.DBInstanceIdentifier)
+		}
+		items = append(items, item)
+	}
+	for _, i := range instances {
+		if !strings.EqualFold(i.DBClusterIdentifier, "") {
+			continue
+		}
+		items = append(items, InventoryItem{
+			Account:       t.account,
+			Region:        t.region,
+			Kind:          InventoryKindInstance,
+			Identifier:    i.DBInstanceIdentifier,
+			Arn:           i.DBInstanceArn,
+			Engine:        i.Engine,
+			EngineVersion: i.EngineVersion,
+			Status:        i.DBInstanceStatus,
+			Endpoint:      i.Endpoint,
+			Tags:          i.Tags,
+			Instance:      i,
+		})
+	}
+	return items, nil
+}
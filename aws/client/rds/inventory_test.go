@@ -0,0 +1,143 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/database-mesh/golang-sdk/aws/client/rds/fake"
+)
+
+var errAccessDenied = errors.New("access denied")
+
+type deniedAPI struct {
+	API
+}
+
+func (deniedAPI) DescribeDBClusters(context.Context, *rds.DescribeDBClustersInput, ...func(*rds.Options)) (*rds.DescribeDBClustersOutput, error) {
+	return nil, errAccessDenied
+}
+
+func Test_ListInventory(t *testing.T) {
+	east := fake.New()
+	svc := NewServiceWithAPI(east)
+	err := svc.Aurora().
+		SetEngine("aurora-mysql").
+		SetDBClusterIdentifier("foo").
+		SetDBInstanceIdentifier("foo-instance-1").
+		SetDBInstanceClass("db.r5.large").
+		CreateWithPrimary(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	err = svc.Cluster().
+		SetEngine("mysql").
+		SetDBClusterIdentifier("bar").
+		SetDBClusterInstanceClass("db.m5d.large").
+		Create(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+
+	west := fake.New().SetRegion("us-west-2")
+	err = NewServiceWithAPI(west).Instance().
+		SetEngine("postgres").
+		SetDBInstanceIdentifier("baz").
+		SetDBInstanceClass("db.m5.large").
+		SetAllocatedStorage(40).
+		Create(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+
+	inv := listInventory(context.TODO(), []inventoryTarget{
+		{account: "111111111111", region: "us-east-1", core: east},
+		{account: "111111111111", region: "us-west-2", core: west},
+		{account: "222222222222", region: "us-east-1", core: deniedAPI{API: fake.New()}},
+	}, WithParallelism(2))
+
+	if len(inv.Errors) != 1 || inv.Errors[0].Account != "222222222222" || !errors.Is(inv.Errors[0], errAccessDenied) {
+		t.Fatalf("unexpected errors %+v\n", inv.Errors)
+	}
+	expected := []struct {
+		region string
+		kind   InventoryKind
+		id     string
+	}{
+		{"us-east-1", InventoryKindAuroraCluster, "foo"},
+		{"us-east-1", InventoryKindMultiAZCluster, "bar"},
+		{"us-west-2", InventoryKindInstance, "baz"},
+	}
+	if len(inv.Items) != len(expected) {
+		t.Fatalf("unexpected items %#v\n", inv.Items)
+	}
+	for n, e := range expected {
+		item := inv.Items[n]
+		if item.Region != e.region || item.Kind != e.kind || item.Identifier != e.id {
+			t.Fatalf("expected %v, got %s %s %s\n", e, item.Region, item.Kind, item.Identifier)
+		}
+	}
+	if len(inv.Items[1].Members) != 3 {
+		t.Fatalf("expected 3 members, got %v\n", inv.Items[1].Members)
+	}
+}
+
+// blockingAPI blocks listing clusters until the context ends.
+type blockingAPI struct {
+	API
+	calls   *int32
+	started chan struct{}
+}
+
+func (b blockingAPI) DescribeDBClusters(ctx context.Context, _ *rds.DescribeDBClustersInput, _ ...func(*rds.Options)) (*rds.DescribeDBClustersOutput, error) {
+	atomic.AddInt32(b.calls, 1)
+	b.started <- struct{}{}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func Test_ListInventoryCanceled(t *testing.T) {
+	var calls int32
+	started := make(chan struct{}, 3)
+	core := blockingAPI{API: fake.New(), calls: &calls, started: started}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	go func() {
+		<-started
+		cancel()
+	}()
+	inv := listInventory(ctx, []inventoryTarget{
+		{account: "111111111111", region: "us-east-1", core: core},
+		{account: "111111111111", region: "us-west-2", core: core},
+		{account: "222222222222", region: "us-east-1", core: core},
+	}, WithParallelism(1))
+
+	if len(inv.Errors) != 3 {
+		t.Fatalf("unexpected errors %+v\n", inv.Errors)
+	}
+	for _, err := range inv.Errors {
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %+v\n", err)
+		}
+	}
+	// Queued regions are not listed once the context is canceled.
+	if calls != 1 {
+		t.Fatalf("expected 1 region listed, got %d\n", calls)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+// AuroraEngines are the engines listed by Aurora.List unless the filter
+// names others.
+var AuroraEngines = []string{"aurora", "aurora-mysql", "aurora-postgresql"}
+
+// ListFilter narrows the databases returned by List. A nil or zero filter
+// returns everything.
+type ListFilter struct {
+	// Engines is sent to RDS as the engine filter.
+	Engines []string
+	// Statuses keeps the databases in one of the given statuses.
+	Statuses []string
+	// Tags keeps the databases carrying all of the given tags. An empty
+	// value matches any value of the key.
+	Tags map[string]string
+	// MaxRecords is the page size, between 20 and 100. RDS uses 100 when
+	// it is zero.
+	MaxRecords int32
+}
+
+func (f *ListFilter) filters() []types.Filter {
+	if f == nil || len(f.Engines) == 0 {
+		return nil
+	}
+	return []types.Filter{{Name: aws.String("engine"), Values: f.Engines}}
+}
+
+func (f *ListFilter) maxRecords() *int32 {
+	if f == nil || f.MaxRecords == 0 {
+		return nil
+	}
+	return aws.Int32(f.MaxRecords)
+}
+
+func (f *ListFilter) match(status string, tags map[string]string) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.Statuses) > 0 && !contains(f.Statuses, status) {
+		return false
+	}
+	for k, v := range f.Tags {
+		tv, ok := tags[k]
+		if !ok || (v != "" && v != tv) {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func convertTags(tags []types.Tag) map[string]string {
+	if len(tags) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(tags))
+	for _, t := range tags {
+		m[aws.ToString(t.Key)] = aws.ToString(t.Value)
+	}
+	return m
+}
+
+// List returns all instances matching the filter, following pagination.
+func (s *rdsInstance) List(ctx context.Context, filter *ListFilter) ([]*DescInstance, error) {
+	paginator := rds.NewDescribeDBInstancesPaginator(s.core, &rds.DescribeDBInstancesInput{
+		Filters:    filter.filters(),
+		MaxRecords: filter.maxRecords(),
+	})
+
+	descs := []*DescInstance{}
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range output.DBInstances {
+			desc := convertDBInstance(i)
+			if filter.match(desc.DBInstanceStatus, desc.Tags) {
+				descs = append(descs, desc)
+			}
+		}
+	}
+	return descs, nil
+}
+
+func listClusters(ctx context.Context, core API, filter *ListFilter) ([]*DescCluster, error) {
+	paginator := rds.NewDescribeDBClustersPaginator(core, &rds.DescribeDBClustersInput{
+		Filters:    filter.filters(),
+		MaxRecords: filter.maxRecords(),
+	})
+
+	descs := []*DescCluster{}
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range output.DBClusters {
+			desc := convertDBCluster(c)
+			if filter.match(desc.Status, desc.Tags) {
+				descs = append(descs, desc)
+			}
+		}
+	}
+	return descs, nil
+}
+
+// List returns all clusters matching the filter, following pagination.
+// Both Aurora and Multi-AZ DB clusters are returned.
+func (s *rdsCluster) List(ctx context.Context, filter *ListFilter) ([]*DescCluster, error) {
+	return listClusters(ctx, s.core, filter)
+}
+
+// List returns the Aurora clusters matching the filter, following
+// pagination. The filter defaults to AuroraEngines if it names no engine.
+func (s *rdsAurora) List(ctx context.Context, filter *ListFilter) ([]*DescCluster, error) {
+	f := ListFilter{}
+	if filter != nil {
+		f = *filter
+	}
+	if len(f.Engines) == 0 {
+		f.Engines = AuroraEngines
+	}
+	return listClusters(ctx, s.core, &f)
+}
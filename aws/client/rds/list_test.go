@@ -0,0 +1,123 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/database-mesh/golang-sdk/aws/client/rds/fake"
+)
+
+func Test_ListInstances(t *testing.T) {
+	f := fake.New()
+	svc := NewServiceWithAPI(f)
+
+	for n := 0; n < 45; n++ {
+		engine, team := "mysql", "a"
+		if n%3 == 0 {
+			engine, team = "postgres", "b"
+		}
+		_, err := f.CreateDBInstance(context.TODO(), &rds.CreateDBInstanceInput{
+			DBInstanceIdentifier: aws.String(fmt.Sprintf("foo-%02d", n)),
+			DBInstanceClass:      aws.String("db.m5.large"),
+			Engine:               aws.String(engine),
+			Tags:                 []types.Tag{{Key: aws.String("team"), Value: aws.String(team)}},
+		})
+		if err != nil {
+			t.Fatalf("%+v\n", err)
+		}
+	}
+
+	descs, err := svc.Instance().List(context.TODO(), &ListFilter{MaxRecords: 20})
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(descs) != 45 {
+		t.Fatalf("expected 45 instances, got %d\n", len(descs))
+	}
+
+	descs, err = svc.Instance().List(context.TODO(), &ListFilter{Engines: []string{"postgres"}, MaxRecords: 20})
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(descs) != 15 || descs[0].Engine != "postgres" {
+		t.Fatalf("unexpected instances %#v\n", descs)
+	}
+
+	descs, err = svc.Instance().List(context.TODO(), &ListFilter{
+		Statuses: []string{StatusAvailable},
+		Tags:     map[string]string{"team": "a"},
+	})
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(descs) != 0 {
+		t.Fatalf("expected no available instance, got %d\n", len(descs))
+	}
+
+	f.Settle()
+	descs, err = svc.Instance().List(context.TODO(), &ListFilter{
+		Statuses: []string{StatusAvailable},
+		Tags:     map[string]string{"team": "a"},
+	})
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(descs) != 30 {
+		t.Fatalf("expected 30 instances, got %d\n", len(descs))
+	}
+}
+
+func Test_ListClusters(t *testing.T) {
+	f := fake.New()
+	svc := NewServiceWithAPI(f)
+
+	err := svc.Aurora().
+		SetEngine("aurora-mysql").
+		SetDBClusterIdentifier("foo").
+		Create(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	err = svc.Cluster().
+		SetEngine("mysql").
+		SetDBClusterIdentifier("bar").
+		SetDBClusterInstanceClass("db.m5d.large").
+		Create(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+
+	descs, err := svc.Cluster().List(context.TODO(), nil)
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(descs) != 2 {
+		t.Fatalf("expected 2 clusters, got %d\n", len(descs))
+	}
+
+	descs, err = svc.Aurora().List(context.TODO(), nil)
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(descs) != 1 || descs[0].DBClusterIdentifier != "foo" {
+		t.Fatalf("unexpected clusters %#v\n", descs)
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+)
+
+// downloadPortionLines is the number of lines requested per portion. RDS
+// truncates portions larger than 1 MB, so it is kept well below its limit.
+const downloadPortionLines int32 = 1000
+
+// LogFile is a log file of an instance, such as error/mysql-error.log or
+// slowquery/mysql-slowquery.log.
+type LogFile struct {
+	LogFileName string
+	LastWritten time.Time
+	// Size is in bytes.
+	Size int64
+}
+
+// ListLogFiles returns the log files of the instance.
+func (s *rdsInstance) ListLogFiles(ctx context.Context) ([]*LogFile, error) {
+	paginator := rds.NewDescribeDBLogFilesPaginator(s.core, &rds.DescribeDBLogFilesInput{
+		DBInstanceIdentifier: s.describeInstanceParam.DBInstanceIdentifier,
+	})
+
+	files := []*LogFile{}
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range output.DescribeDBLogFiles {
+			files = append(files, &LogFile{
+				LogFileName: aws.ToString(l.LogFileName),
+				LastWritten: time.UnixMilli(l.LastWritten).UTC(),
+				Size:        l.Size,
+			})
+		}
+	}
+	return files, nil
+}
+
+// DownloadLogFile returns the log file from its beginning. The file is
+// downloaded portion by portion while it is read, errors after the first
+// portion are returned by Read.
+func (s *rdsInstance) DownloadLogFile(ctx context.Context, name string) (io.Reader, error) {
+	return newLogFileReader(ctx, s.core, &rds.DownloadDBLogFilePortionInput{
+		DBInstanceIdentifier: s.describeInstanceParam.DBInstanceIdentifier,
+		LogFileName:          aws.String(name),
+		Marker:               aws.String("0"),
+		NumberOfLines:        downloadPortionLines,
+	}, true)
+}
+
+// TailLogFile returns the last lines of the log file.
+// NOTE: RDS returns at most 1 MB of lines at once.
+func (s *rdsInstance) TailLogFile(ctx context.Context, name string, lines int32) (io.Reader, error) {
+	return newLogFileReader(ctx, s.core, &rds.DownloadDBLogFilePortionInput{
+		DBInstanceIdentifier: s.describeInstanceParam.DBInstanceIdentifier,
+		LogFileName:          aws.String(name),
+		NumberOfLines:        lines,
+	}, false)
+}
+
+// logFileReader reads the portions of a log file, following their markers.
+type logFileReader struct {
+	ctx   context.Context
+	core  API
+	param *rds.DownloadDBLogFilePortionInput
+
+	data []byte
+	more bool
+	err  error
+}
+
+// newLogFileReader downloads the first portion, so that a missing instance
+// or file is reported before reading. Further portions are downloaded only
+// when follow is set.
+func newLogFileReader(ctx context.Context, core API, param *rds.DownloadDBLogFilePortionInput, follow bool) (*logFileReader, error) {
+	r := &logFileReader{ctx: ctx, core: core, param: param}
+	if err := r.fetch(); err != nil {
+		return nil, err
+	}
+	r.more = r.more && follow
+	return r, nil
+}
+
+func (r *logFileReader) fetch() error {
+	output, err := r.core.DownloadDBLogFilePortion(r.ctx, r.param)
+	if err != nil {
+		return err
+	}
+	r.data = append(r.data, aws.ToString(output.LogFileData)...)
+	// A marker which does not move would download the same portion forever.
+	r.more = output.AdditionalDataPending && aws.ToString(output.Marker) != aws.ToString(r.param.Marker)
+	r.param.Marker = output.Marker
+	return nil
+}
+
+func (r *logFileReader) Read(p []byte) (int, error) {
+	for len(r.data) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if !r.more {
+			return 0, io.EOF
+		}
+		r.err = r.fetch()
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
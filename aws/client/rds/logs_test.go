@@ -0,0 +1,86 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/database-mesh/golang-sdk/aws/client/rds/fake"
+)
+
+func Test_LogFiles(t *testing.T) {
+	f := fake.New().SetAutoAdvance(true)
+	svc := NewServiceWithAPI(f)
+
+	instance := svc.Instance().
+		SetEngine("mysql").
+		SetEngineVersion("8.0.28").
+		SetDBInstanceIdentifier(TestDBIdentifier).
+		SetDBInstanceClass("db.m5.large").
+		SetAllocatedStorage(40)
+	if err := instance.Create(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	lines := []string{}
+	for n := 0; n < 2500; n++ {
+		lines = append(lines, fmt.Sprintf("# Query_time: %d", n))
+	}
+	f.WriteLog(TestDBIdentifier, "slowquery/mysql-slowquery.log", lines...)
+
+	files, err := instance.ListLogFiles(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(files) != 2 || files[0].LogFileName != "error/mysql-error.log" || files[1].LogFileName != "slowquery/mysql-slowquery.log" {
+		t.Fatalf("unexpected log files %#v\n", files)
+	}
+	expected := strings.Join(lines, "\n") + "\n"
+	if files[1].Size != int64(len(expected)) || files[1].LastWritten.IsZero() {
+		t.Fatalf("unexpected log file %#v\n", files[1])
+	}
+
+	// Files larger than a portion are downloaded portion by portion.
+	r, err := instance.DownloadLogFile(context.TODO(), "slowquery/mysql-slowquery.log")
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if string(data) != expected {
+		t.Fatalf("unexpected log file of %d bytes\n", len(data))
+	}
+
+	r, err = instance.TailLogFile(context.TODO(), "slowquery/mysql-slowquery.log", 2)
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	data, err = io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if string(data) != "# Query_time: 2498\n# Query_time: 2499\n" {
+		t.Fatalf("unexpected tail %q\n", data)
+	}
+
+	if _, err := instance.DownloadLogFile(context.TODO(), "error/postgresql.log"); err == nil {
+		t.Fatalf("expected log file not found error\n")
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/database-mesh/golang-sdk/aws/client/rds/fake"
+)
+
+func Test_ModifyInstance(t *testing.T) {
+	f := fake.New()
+	svc := NewServiceWithAPI(f)
+
+	err := svc.Instance().
+		SetEngine("mysql").
+		SetEngineVersion("5.7.40").
+		SetDBInstanceIdentifier(TestDBIdentifier).
+		SetDBInstanceClass("db.m5.large").
+		SetAllocatedStorage(40).
+		Create(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	f.Settle()
+
+	// Deferred to the maintenance window.
+	err = svc.Instance().
+		SetDBInstanceIdentifier(TestDBIdentifier).
+		SetDBInstanceClass("db.m5.xlarge").
+		SetAllocatedStorage(100).
+		Modify(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	desc, err := svc.Instance().SetDBInstanceIdentifier(TestDBIdentifier).Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if !desc.PendingModifications || desc.DBInstanceClass != "db.m5.large" {
+		t.Fatalf("unexpected instance %#v\n", desc)
+	}
+	f.ApplyPendingModifications()
+	f.Settle()
+	desc, err = svc.Instance().SetDBInstanceIdentifier(TestDBIdentifier).Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if desc.PendingModifications || desc.DBInstanceClass != "db.m5.xlarge" {
+		t.Fatalf("unexpected instance %#v\n", desc)
+	}
+
+	// A major upgrade must be allowed explicitly.
+	err = svc.Instance().
+		SetDBInstanceIdentifier(TestDBIdentifier).
+		SetEngineVersion("8.0.28").
+		SetApplyImmediately(true).
+		Modify(context.TODO())
+	if err == nil {
+		t.Fatalf("expected major version upgrade error\n")
+	}
+	err = svc.Instance().
+		SetDBInstanceIdentifier(TestDBIdentifier).
+		SetEngineVersion("8.0.28").
+		SetAllowMajorVersionUpgrade(true).
+		SetApplyImmediately(true).
+		Modify(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	desc, err = svc.Instance().SetDBInstanceIdentifier(TestDBIdentifier).Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if desc.DBInstanceStatus != fake.StatusModifying || desc.EngineVersion != "8.0.28" {
+		t.Fatalf("unexpected instance %#v\n", desc)
+	}
+}
+
+func Test_ModifyCluster(t *testing.T) {
+	f := fake.New()
+	svc := NewServiceWithAPI(f)
+
+	err := svc.Cluster().
+		SetEngine("aurora-postgresql").
+		SetEngineVersion("14.5").
+		SetDBClusterIdentifier(TestDBIdentifier).
+		Create(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	f.Settle()
+
+	err = svc.Cluster().
+		SetDBClusterIdentifier(TestDBIdentifier).
+		SetEngineVersion("14.6").
+		SetDeletionProtection(true).
+		Modify(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	desc, err := svc.Cluster().SetDBClusterIdentifier(TestDBIdentifier).Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if !desc.PendingModifications || !desc.DeletionProtection || desc.EngineVersion != "14.5" {
+		t.Fatalf("unexpected cluster %#v\n", desc)
+	}
+}
+
+func Test_EmptyPendingModifications(t *testing.T) {
+	// RDS may keep an empty PendingModifiedValues once modifications are
+	// applied.
+	cluster := convertDBCluster(types.DBCluster{
+		PendingModifiedValues: &types.ClusterPendingModifiedValues{PendingCloudwatchLogsExports: &types.PendingCloudwatchLogsExports{}},
+	})
+	if cluster.PendingModifications {
+		t.Fatalf("unexpected pending modifications %#v\n", cluster)
+	}
+	instance := convertDBInstance(types.DBInstance{PendingModifiedValues: &types.PendingModifiedValues{}})
+	if instance.PendingModifications {
+		t.Fatalf("unexpected pending modifications %#v\n", instance)
+	}
+	cluster = convertDBCluster(types.DBCluster{
+		PendingModifiedValues: &types.ClusterPendingModifiedValues{EngineVersion: aws.String("8.0.mysql_aurora.3.02.2")},
+	})
+	if !cluster.PendingModifications {
+		t.Fatalf("expected pending modifications %#v\n", cluster)
+	}
+}
@@ -0,0 +1,453 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+const (
+	ApplyMethodImmediate     = string(types.ApplyMethodImmediate)
+	ApplyMethodPendingReboot = string(types.ApplyMethodPendingReboot)
+
+	ApplyTypeStatic  = "static"
+	ApplyTypeDynamic = "dynamic"
+
+	// ApplyStatusPendingReboot is the status of parameter groups whose
+	// changes wait for the instances to be rebooted.
+	ApplyStatusPendingReboot = "pending-reboot"
+
+	// ParameterSourceUser selects the parameters set on the group, see
+	// SetSource.
+	ParameterSourceUser          = "user"
+	ParameterSourceEngineDefault = "engine-default"
+	ParameterSourceSystem        = "system"
+)
+
+// maxModifyParameters is the number of parameters RDS accepts per Modify or
+// Reset call.
+const maxModifyParameters = 20
+
+// ParameterGroup manages the DB parameter groups of instances, or the DB
+// cluster parameter groups of clusters, see RDS.ParameterGroup and
+// RDS.ClusterParameterGroup.
+type ParameterGroup interface {
+	SetDBParameterGroupName(name string) ParameterGroup
+	SetDBParameterGroupFamily(family string) ParameterGroup
+	SetDescription(desc string) ParameterGroup
+	// SetParameters sets the parameters changed by Modify or reset by
+	// Reset. Parameters without ApplyMethod are applied immediately when
+	// they are dynamic and on the next reboot otherwise.
+	SetParameters(params ...Parameter) ParameterGroup
+	// SetSource restricts ListParameters to a source, e.g.
+	// ParameterSourceUser.
+	SetSource(source string) ParameterGroup
+	SetTags(tags map[string]string) ParameterGroup
+
+	Create(context.Context) error
+	Modify(context.Context) error
+	Reset(context.Context) error
+	Delete(context.Context) error
+	Describe(context.Context) (*DescParameterGroup, error)
+	ListParameters(context.Context) ([]Parameter, error)
+	Diff(ctx context.Context, other string) ([]ParameterDiff, error)
+	DiffDeclared(context.Context) ([]ParameterDiff, error)
+}
+
+type Parameter struct {
+	Name  string
+	Value string
+	// ApplyMethod is ApplyMethodImmediate or ApplyMethodPendingReboot.
+	ApplyMethod string
+	// ApplyType is ApplyTypeStatic or ApplyTypeDynamic, static parameters
+	// need a reboot.
+	ApplyType     string
+	DataType      string
+	AllowedValues string
+	Source        string
+	Description   string
+	IsModifiable  bool
+}
+
+// ParameterDiff is a parameter whose value differs, an empty value is an
+// unset parameter.
+type ParameterDiff struct {
+	Name       string
+	Value      string
+	OtherValue string
+}
+
+type DescParameterGroup struct {
+	DBParameterGroupName   string
+	DBParameterGroupArn    string
+	DBParameterGroupFamily string
+	Description            string
+}
+
+type rdsParameterGroup struct {
+	core       API
+	cluster    bool
+	parameters []Parameter
+	source     *string
+
+	createParam          *rds.CreateDBParameterGroupInput
+	deleteParam          *rds.DeleteDBParameterGroupInput
+	describeParam        *rds.DescribeDBParameterGroupsInput
+	createClusterParam   *rds.CreateDBClusterParameterGroupInput
+	deleteClusterParam   *rds.DeleteDBClusterParameterGroupInput
+	describeClusterParam *rds.DescribeDBClusterParameterGroupsInput
+}
+
+func newParameterGroup(core API, cluster bool) *rdsParameterGroup {
+	return &rdsParameterGroup{
+		core:                 core,
+		cluster:              cluster,
+		createParam:          &rds.CreateDBParameterGroupInput{},
+		deleteParam:          &rds.DeleteDBParameterGroupInput{},
+		describeParam:        &rds.DescribeDBParameterGroupsInput{},
+		createClusterParam:   &rds.CreateDBClusterParameterGroupInput{},
+		deleteClusterParam:   &rds.DeleteDBClusterParameterGroupInput{},
+		describeClusterParam: &rds.DescribeDBClusterParameterGroupsInput{},
+	}
+}
+
+// SetDBParameterGroupName sets the name of the group, which is a DB cluster
+// parameter group for RDS.ClusterParameterGroup.
+func (s *rdsParameterGroup) SetDBParameterGroupName(name string) ParameterGroup {
+	s.createParam.DBParameterGroupName = aws.String(name)
+	s.deleteParam.DBParameterGroupName = aws.String(name)
+	s.describeParam.DBParameterGroupName = aws.String(name)
+	s.createClusterParam.DBClusterParameterGroupName = aws.String(name)
+	s.deleteClusterParam.DBClusterParameterGroupName = aws.String(name)
+	s.describeClusterParam.DBClusterParameterGroupName = aws.String(name)
+	return s
+}
+
+// SetDBParameterGroupFamily sets the family, e.g. mysql8.0 or
+// aurora-mysql8.0, which must match the engine of the databases using the
+// group.
+func (s *rdsParameterGroup) SetDBParameterGroupFamily(family string) ParameterGroup {
+	s.createParam.DBParameterGroupFamily = aws.String(family)
+	s.createClusterParam.DBParameterGroupFamily = aws.String(family)
+	return s
+}
+
+func (s *rdsParameterGroup) SetDescription(desc string) ParameterGroup {
+	s.createParam.Description = aws.String(desc)
+	s.createClusterParam.Description = aws.String(desc)
+	return s
+}
+
+func (s *rdsParameterGroup) SetParameters(params ...Parameter) ParameterGroup {
+	s.parameters = params
+	return s
+}
+
+func (s *rdsParameterGroup) SetSource(source string) ParameterGroup {
+	s.source = aws.String(source)
+	return s
+}
+
+// SetTags sets the tags of the group created by Create. Tags set several
+// times are merged.
+func (s *rdsParameterGroup) SetTags(tags map[string]string) ParameterGroup {
+	s.createParam.Tags = mergeTags(s.createParam.Tags, tags)
+	s.createClusterParam.Tags = mergeTags(s.createClusterParam.Tags, tags)
+	return s
+}
+
+func (s *rdsParameterGroup) name() *string {
+	return s.describeParam.DBParameterGroupName
+}
+
+func (s *rdsParameterGroup) Create(ctx context.Context) error {
+	if s.cluster {
+		_, err := s.core.CreateDBClusterParameterGroup(ctx, s.createClusterParam)
+		return err
+	}
+	_, err := s.core.CreateDBParameterGroup(ctx, s.createParam)
+	return err
+}
+
+// Modify sets the parameters of SetParameters, in batches of 20 which is
+// the limit of RDS.
+func (s *rdsParameterGroup) Modify(ctx context.Context) error {
+	params, err := s.withApplyMethods(ctx)
+	if err != nil {
+		return err
+	}
+	return inBatches(params, func(batch []types.Parameter) error {
+		var err error
+		if s.cluster {
+			_, err = s.core.ModifyDBClusterParameterGroup(ctx, &rds.ModifyDBClusterParameterGroupInput{DBClusterParameterGroupName: s.name(), Parameters: batch})
+		} else {
+			_, err = s.core.ModifyDBParameterGroup(ctx, &rds.ModifyDBParameterGroupInput{DBParameterGroupName: s.name(), Parameters: batch})
+		}
+		return err
+	})
+}
+
+// Reset restores the engine defaults of the parameters of SetParameters,
+// or of every parameter when none is set.
+func (s *rdsParameterGroup) Reset(ctx context.Context) error {
+	if len(s.parameters) == 0 {
+		var err error
+		if s.cluster {
+			_, err = s.core.ResetDBClusterParameterGroup(ctx, &rds.ResetDBClusterParameterGroupInput{DBClusterParameterGroupName: s.name(), ResetAllParameters: true})
+		} else {
+			_, err = s.core.ResetDBParameterGroup(ctx, &rds.ResetDBParameterGroupInput{DBParameterGroupName: s.name(), ResetAllParameters: true})
+		}
+		return err
+	}
+
+	params, err := s.withApplyMethods(ctx)
+	if err != nil {
+		return err
+	}
+	for n := range params {
+		params[n].ParameterValue = nil
+	}
+	return inBatches(params, func(batch []types.Parameter) error {
+		var err error
+		if s.cluster {
+			_, err = s.core.ResetDBClusterParameterGroup(ctx, &rds.ResetDBClusterParameterGroupInput{DBClusterParameterGroupName: s.name(), Parameters: batch})
+		} else {
+			_, err = s.core.ResetDBParameterGroup(ctx, &rds.ResetDBParameterGroupInput{DBParameterGroupName: s.name(), Parameters: batch})
+		}
+		return err
+	})
+}
+
+// inBatches calls fn with the parameters, maxModifyParameters at a time,
+// stopping at the first error.
+func inBatches(params []types.Parameter, fn func(batch []types.Parameter) error) error {
+	for start := 0; start < len(params); start += maxModifyParameters {
+		end := start + maxModifyParameters
+		if end > len(params) {
+			end = len(params)
+		}
+		if err := fn(params[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withApplyMethods converts the parameters of SetParameters, looking up the
+// apply type of the ones without ApplyMethod.
+func (s *rdsParameterGroup) withApplyMethods(ctx context.Context) ([]types.Parameter, error) {
+	applyTypes := map[string]string{}
+	for _, p := range s.parameters {
+		if p.ApplyMethod != "" {
+			continue
+		}
+		current, err := s.listParameters(ctx, s.name(), nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range current {
+			applyTypes[c.Name] = c.ApplyType
+		}
+		break
+	}
+
+	params := []types.Parameter{}
+	for _, p := range s.parameters {
+		method := p.ApplyMethod
+		if method == "" {
+			method = ApplyMethodImmediate
+			if applyTypes[p.Name] == ApplyTypeStatic {
+				method = ApplyMethodPendingReboot
+			}
+		}
+		params = append(params, types.Parameter{
+			ParameterName:  aws.String(p.Name),
+			ParameterValue: aws.String(p.Value),
+			ApplyMethod:    types.ApplyMethod(method),
+		})
+	}
+	return params, nil
+}
+
+// Delete deletes the group, which must not be used by any database.
+func (s *rdsParameterGroup) Delete(ctx context.Context) error {
+	if s.cluster {
+		_, err := s.core.DeleteDBClusterParameterGroup(ctx, s.deleteClusterParam)
+		return err
+	}
+	_, err := s.core.DeleteDBParameterGroup(ctx, s.deleteParam)
+	return err
+}
+
+func (s *rdsParameterGroup) Describe(ctx context.Context) (*DescParameterGroup, error) {
+	desc := &DescParameterGroup{}
+	if s.cluster {
+		output, err := s.core.DescribeDBClusterParameterGroups(ctx, s.describeClusterParam)
+		if err != nil {
+			return nil, err
+		}
+		if len(output.DBClusterParameterGroups) > 0 {
+			g := output.DBClusterParameterGroups[0]
+			desc.DBParameterGroupName = aws.ToString(g.DBClusterParameterGroupName)
+			desc.DBParameterGroupArn = aws.ToString(g.DBClusterParameterGroupArn)
+			desc.DBParameterGroupFamily = aws.ToString(g.DBParameterGroupFamily)
+			desc.Description = aws.ToString(g.Description)
+		}
+		return desc, nil
+	}
+
+	output, err := s.core.DescribeDBParameterGroups(ctx, s.describeParam)
+	if err != nil {
+		return nil, err
+	}
+	if len(output.DBParameterGroups) > 0 {
+		g := output.DBParameterGroups[0]
+		desc.DBParameterGroupName = aws.ToString(g.DBParameterGroupName)
+		desc.DBParameterGroupArn = aws.ToString(g.DBParameterGroupArn)
+		desc.DBParameterGroupFamily = aws.ToString(g.DBParameterGroupFamily)
+		desc.Description = aws.ToString(g.Description)
+	}
+	return desc, nil
+}
+
+// ListParameters returns the effective parameters of the group, i.e. the
+// engine defaults overridden by the values set on the group.
+func (s *rdsParameterGroup) ListParameters(ctx context.Context) ([]Parameter, error) {
+	return s.listParameters(ctx, s.name(), s.source)
+}
+
+func (s *rdsParameterGroup) listParameters(ctx context.Context, name, source *string) ([]Parameter, error) {
+	params := []Parameter{}
+	if s.cluster {
+		paginator := rds.NewDescribeDBClusterParametersPaginator(s.core, &rds.DescribeDBClusterParametersInput{DBClusterParameterGroupName: name, Source: source})
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, p := range output.Parameters {
+				params = append(params, convertParameter(p))
+			}
+		}
+		return params, nil
+	}
+
+	paginator := rds.NewDescribeDBParametersPaginator(s.core, &rds.DescribeDBParametersInput{DBParameterGroupName: name, Source: source})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range output.Parameters {
+			params = append(params, convertParameter(p))
+		}
+	}
+	return params, nil
+}
+
+func convertParameter(p types.Parameter) Parameter {
+	return Parameter{
+		Name:          aws.ToString(p.ParameterName),
+		Value:         aws.ToString(p.ParameterValue),
+		ApplyMethod:   string(p.ApplyMethod),
+		ApplyType:     aws.ToString(p.ApplyType),
+		DataType:      aws.ToString(p.DataType),
+		AllowedValues: aws.ToString(p.AllowedValues),
+		Source:        aws.ToString(p.Source),
+		Description:   aws.ToString(p.Description),
+		IsModifiable:  p.IsModifiable,
+	}
+}
+
+// Diff compares the effective parameters of the group with the ones of the
+// other group of the same kind, e.g. to review a group against the default
+// one of its family.
+func (s *rdsParameterGroup) Diff(ctx context.Context, other string) ([]ParameterDiff, error) {
+	params, err := s.listParameters(ctx, s.name(), nil)
+	if err != nil {
+		return nil, err
+	}
+	others, err := s.listParameters(ctx, aws.String(other), nil)
+	if err != nil {
+		return nil, err
+	}
+	return diffParameters(params, others, true), nil
+}
+
+// DiffDeclared compares the parameters of SetParameters with the effective
+// ones, i.e. it returns what Modify would change. Value is the declared one.
+func (s *rdsParameterGroup) DiffDeclared(ctx context.Context) ([]ParameterDiff, error) {
+	params, err := s.listParameters(ctx, s.name(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return diffParameters(s.parameters, params, false), nil
+}
+
+// diffParameters returns the parameters of values whose value differs in
+// others. Parameters only found in others are included when both is set.
+func diffParameters(values, others []Parameter, both bool) []ParameterDiff {
+	otherValues := map[string]string{}
+	for _, p := range others {
+		otherValues[p.Name] = p.Value
+	}
+	diffs := []ParameterDiff{}
+	seen := map[string]struct{}{}
+	for _, p := range values {
+		seen[p.Name] = struct{}{}
+		if other := otherValues[p.Name]; other != p.Value {
+			diffs = append(diffs, ParameterDiff{Name: p.Name, Value: p.Value, OtherValue: other})
+		}
+	}
+	if both {
+		for _, p := range others {
+			if _, ok := seen[p.Name]; !ok && p.Value != "" {
+				diffs = append(diffs, ParameterDiff{Name: p.Name, OtherValue: p.Value})
+			}
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Name < diffs[j].Name
+	})
+	return diffs
+}
+
+// PendingReboot reports whether changes of the parameter groups of the
+// instance wait for a reboot.
+func (d *DescInstance) PendingReboot() bool {
+	for _, g := range d.DBParameterGroups {
+		if g.ApplyStatus == ApplyStatusPendingReboot {
+			return true
+		}
+	}
+	return false
+}
+
+// PendingRebootMembers returns the instances of the cluster which need a
+// reboot to apply the changes of the DB cluster parameter group.
+func (d *DescCluster) PendingRebootMembers() []string {
+	ids := []string{}
+	for _, m := range d.DBClusterMembers {
+		if m.DBClusterParameterGroupStatus == ApplyStatusPendingReboot {
+			ids = append(ids, m.DBInstanceIdentifier)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,251 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/database-mesh/golang-sdk/aws/client/rds/fake"
+)
+
+func Test_ParameterGroup(t *testing.T) {
+	f := fake.New()
+	svc := NewServiceWithAPI(f)
+
+	pg := svc.ParameterGroup().
+		SetDBParameterGroupName("foo-pg").
+		SetDBParameterGroupFamily("mysql8.0").
+		SetDescription("foo parameters")
+	if err := pg.Create(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	err := svc.Instance().
+		SetEngine("mysql").
+		SetEngineVersion("8.0.28").
+		SetDBInstanceIdentifier(TestDBIdentifier).
+		SetDBInstanceClass("db.m5.large").
+		SetAllocatedStorage(40).
+		SetDBParameterGroupName("foo-pg").
+		Create(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	f.Settle()
+
+	// Invalid values are rejected.
+	if err := pg.SetParameters(Parameter{Name: "slow_query_log", Value: "2"}).Modify(context.TODO()); err == nil {
+		t.Fatalf("expected invalid value error\n")
+	}
+	// Static parameters cannot be applied immediately.
+	err = pg.SetParameters(Parameter{Name: "innodb_buffer_pool_size", Value: "134217728", ApplyMethod: ApplyMethodImmediate}).Modify(context.TODO())
+	if err == nil {
+		t.Fatalf("expected apply method error\n")
+	}
+
+	declared := []Parameter{
+		{Name: "slow_query_log", Value: "1"},
+		{Name: "max_connections", Value: "500"},
+	}
+	diffs, err := pg.SetParameters(declared...).DiffDeclared(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(diffs) != 2 || diffs[0].Name != "max_connections" || diffs[0].Value != "500" {
+		t.Fatalf("unexpected diffs %#v\n", diffs)
+	}
+	if err := pg.Modify(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	desc, err := svc.Instance().SetDBInstanceIdentifier(TestDBIdentifier).Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if desc.PendingReboot() {
+		t.Fatalf("unexpected pending reboot %#v\n", desc.DBParameterGroups)
+	}
+
+	// Static parameters are applied on the next reboot.
+	if err := pg.SetParameters(Parameter{Name: "innodb_buffer_pool_size", Value: "134217728"}).Modify(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	desc, err = svc.Instance().SetDBInstanceIdentifier(TestDBIdentifier).Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if !desc.PendingReboot() {
+		t.Fatalf("expected pending reboot %#v\n", desc.DBParameterGroups)
+	}
+	if err := svc.Instance().SetDBInstanceIdentifier(TestDBIdentifier).Reboot(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	f.Settle()
+	desc, err = svc.Instance().SetDBInstanceIdentifier(TestDBIdentifier).Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if desc.PendingReboot() {
+		t.Fatalf("unexpected pending reboot %#v\n", desc.DBParameterGroups)
+	}
+
+	params, err := pg.SetSource(ParameterSourceUser).ListParameters(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(params) != 3 {
+		t.Fatalf("unexpected parameters %#v\n", params)
+	}
+	diffs, err = pg.Diff(context.TODO(), "default.mysql8.0")
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(diffs) != 3 || diffs[0].Name != "innodb_buffer_pool_size" || diffs[0].OtherValue != "{DBInstanceClassMemory*3/4}" {
+		t.Fatalf("unexpected diffs %#v\n", diffs)
+	}
+
+	if err := pg.SetParameters(Parameter{Name: "max_connections"}).Reset(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	params, err = pg.ListParameters(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(params) != 2 {
+		t.Fatalf("unexpected parameters %#v\n", params)
+	}
+	if err := pg.SetParameters().Reset(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	params, err = pg.ListParameters(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(params) != 0 {
+		t.Fatalf("unexpected parameters %#v\n", params)
+	}
+
+	// Groups in use cannot be deleted.
+	if err := pg.Delete(context.TODO()); err == nil {
+		t.Fatalf("expected parameter group in use error\n")
+	}
+	err = svc.Instance().SetDBInstanceIdentifier(TestDBIdentifier).SetSkipFinalSnapshot(true).Delete(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	f.Settle()
+	if err := pg.Delete(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+}
+
+func Test_ClusterParameterGroup(t *testing.T) {
+	f := fake.New().SetAutoAdvance(true)
+	svc := NewServiceWithAPI(f)
+
+	cpg := svc.ClusterParameterGroup().
+		SetDBParameterGroupName("foo-cluster-pg").
+		SetDBParameterGroupFamily("aurora-mysql8.0").
+		SetDescription("foo cluster parameters")
+	if err := cpg.Create(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	// The family must match the engine.
+	err := svc.Aurora().
+		SetEngine("aurora-postgresql").
+		SetDBClusterIdentifier("bar").
+		SetMasterUsername("admin").
+		SetMasterUserPassword(TestDBPass).
+		SetDBClusterParameterGroupName("foo-cluster-pg").
+		Create(context.TODO())
+	if err == nil {
+		t.Fatalf("expected parameter group family error\n")
+	}
+
+	aurora := svc.Aurora().
+		SetEngine("aurora-mysql").
+		SetDBClusterIdentifier(TestDBIdentifier).
+		SetDBInstanceIdentifier("foo-instance-0").
+		SetDBInstanceClass("db.r5.large").
+		SetMasterUsername("admin").
+		SetMasterUserPassword(TestDBPass).
+		SetDBClusterParameterGroupName("foo-cluster-pg")
+	if err := aurora.CreateWithPrimary(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	f.Settle()
+
+	err = cpg.SetParameters(
+		Parameter{Name: "binlog_format", Value: "ROW"},
+		Parameter{Name: "time_zone", Value: "UTC"},
+	).Modify(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	desc, err := svc.Cluster().SetDBClusterIdentifier(TestDBIdentifier).Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if members := desc.PendingRebootMembers(); len(members) != 1 || members[0] != "foo-instance-0" {
+		t.Fatalf("unexpected pending reboot members %#v\n", members)
+	}
+	if err := svc.Instance().SetDBInstanceIdentifier("foo-instance-0").Reboot(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	desc, err = svc.Cluster().SetDBClusterIdentifier(TestDBIdentifier).Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if members := desc.PendingRebootMembers(); len(members) != 0 {
+		t.Fatalf("unexpected pending reboot members %#v\n", members)
+	}
+
+	diffs, err := cpg.Diff(context.TODO(), "default.aurora-mysql8.0")
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(diffs) != 2 || diffs[0] != (ParameterDiff{Name: "binlog_format", Value: "ROW", OtherValue: "OFF"}) {
+		t.Fatalf("unexpected diffs %#v\n", diffs)
+	}
+	if err := cpg.Delete(context.TODO()); err == nil {
+		t.Fatalf("expected parameter group in use error\n")
+	}
+}
+
+func Test_ParameterBatches(t *testing.T) {
+	params := make([]types.Parameter, 45)
+	sizes := []int{}
+	err := inBatches(params, func(batch []types.Parameter) error {
+		sizes = append(sizes, len(batch))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(sizes) != 3 || sizes[0] != 20 || sizes[1] != 20 || sizes[2] != 5 {
+		t.Fatalf("unexpected batches %v\n", sizes)
+	}
+
+	// The first error stops the batches.
+	calls := 0
+	err = inBatches(params, func([]types.Parameter) error {
+		calls++
+		return errors.New("throttled")
+	})
+	if err == nil || calls != 1 {
+		t.Fatalf("expected error after 1 batch, got %+v after %d\n", err, calls)
+	}
+}
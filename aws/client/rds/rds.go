@@ -17,66 +17,88 @@ package rds
 import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type RDS interface {
 	Instance() Instance
 	Cluster() Cluster
 	Aurora() Aurora
+	Snapshot() Snapshot
+	ParameterGroup() ParameterGroup
+	ClusterParameterGroup() ParameterGroup
+	SubnetGroup() SubnetGroup
+	Tagging() Tagging
 }
 
 type service struct {
-	instance *rdsInstance
-	cluster  *rdsCluster
-	aurora   *rdsAurora
+	core API
+	// tags are set on every builder, see WithTags.
+	tags map[string]string
 }
 
+// Instance returns a new builder on every call. A service can be shared
+// between goroutines as long as each builder is used by one of them only.
 func (s *service) Instance() Instance {
-	return s.instance
+	return newInstance(s.core).SetTags(s.tags)
 }
 
+// Cluster returns a new builder on every call.
 func (s *service) Cluster() Cluster {
-	return s.cluster
+	return newCluster(s.core).SetTags(s.tags)
 }
 
+// Aurora returns a new builder on every call.
 func (s *service) Aurora() Aurora {
-	return s.aurora
+	return newAurora(s.core).SetTags(s.tags)
 }
 
-func NewService(sess aws.Config) *service {
-	return &service{
-		instance: &rdsInstance{
-			core:                     rds.NewFromConfig(sess),
-			createInstanceParam:      &rds.CreateDBInstanceInput{},
-			deleteInstanceParam:      &rds.DeleteDBInstanceInput{},
-			rebootInstanceParam:      &rds.RebootDBInstanceInput{},
-			describeInstanceParam:    &rds.DescribeDBInstancesInput{},
-			restoreInstancePitrParam: &rds.RestoreDBInstanceToPointInTimeInput{},
-		},
-		cluster: &rdsCluster{
-			core:                       rds.NewFromConfig(sess),
-			createClusterParam:         &rds.CreateDBClusterInput{},
-			deleteClusterParam:         &rds.DeleteDBClusterInput{},
-			failoverClusterParam:       &rds.FailoverDBClusterInput{},
-			failoverGlobalClusterParam: &rds.FailoverGlobalClusterInput{},
-			rebootClusterParam:         &rds.RebootDBClusterInput{},
-			describeClusterParam:       &rds.DescribeDBClustersInput{},
-			restoreDBClusterPitrParam:  &rds.RestoreDBClusterToPointInTimeInput{},
-		},
-		aurora: &rdsAurora{
-			core:                       rds.NewFromConfig(sess),
-			createClusterParam:         &rds.CreateDBClusterInput{},
-			deleteClusterParam:         &rds.DeleteDBClusterInput{},
-			failoverClusterParam:       &rds.FailoverDBClusterInput{},
-			failoverGlobalClusterParam: &rds.FailoverGlobalClusterInput{},
-			rebootClusterParam:         &rds.RebootDBClusterInput{},
-			describeClusterParam:       &rds.DescribeDBClustersInput{},
-			restoreDBClusterPitrParam:  &rds.RestoreDBClusterToPointInTimeInput{},
-			createInstanceParam:        &rds.CreateDBInstanceInput{},
-			deleteInstanceParam:        &rds.DeleteDBInstanceInput{},
-			rebootInstanceParam:        &rds.RebootDBInstanceInput{},
-			describeInstanceParam:      &rds.DescribeDBInstancesInput{},
-			restoreInstancePitrParam:   &rds.RestoreDBInstanceToPointInTimeInput{},
-		},
+// Snapshot returns a new builder on every call.
+func (s *service) Snapshot() Snapshot {
+	return newSnapshot(s.core).SetTags(s.tags)
+}
+
+func (s *service) ParameterGroup() ParameterGroup {
+	return newParameterGroup(s.core, false).SetTags(s.tags)
+}
+
+func (s *service) ClusterParameterGroup() ParameterGroup {
+	return newParameterGroup(s.core, true).SetTags(s.tags)
+}
+
+func (s *service) SubnetGroup() SubnetGroup {
+	return newSubnetGroup(s.core).SetTags(s.tags)
+}
+
+func (s *service) Tagging() Tagging {
+	return newTagging(s.core)
+}
+
+// WithTags returns a service whose builders tag everything they create with
+// the tags, on top of the tags of s.
+func (s *service) WithTags(tags map[string]string) *service {
+	merged := map[string]string{}
+	for k, v := range s.tags {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		merged[k] = v
 	}
+	return &service{core: s.core, tags: merged}
+}
+
+// WithOwner returns a service tagging everything it creates with the
+// OwnershipTags of the owner, for provisioning from a DatabaseClass.
+func (s *service) WithOwner(owner metav1.Object, databaseClass string) *service {
+	return s.WithTags(OwnershipTags(owner, databaseClass))
+}
+
+func NewService(sess aws.Config) *service {
+	return NewServiceWithAPI(rds.NewFromConfig(sess))
+}
+
+// NewServiceWithAPI creates a service on top of any implementation of the
+// RDS API, such as the in-memory fake of package fake.
+func NewServiceWithAPI(core API) *service {
+	return &service{core: core}
 }
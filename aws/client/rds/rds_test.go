@@ -28,6 +28,7 @@ const (
 	EnvAWSRegion          = "AWS_REGION"
 	EnvAWSAccessKey       = "AWS_ACCESS_KEY"
 	EnvAWSSecretAccessKey = "AWS_SECRET_ACCESS_KEY"
+	EnvAWSEndpointURL     = "AWS_ENDPOINT_URL"
 
 	TestAWSRegion          = "region-test"
 	TestAWSAccessKey       = "ak-test"
@@ -39,11 +40,21 @@ const (
 	TestDBIdentifier       = "foo"
 )
 
-func Test_CreateRDSInstance(t *testing.T) {
+// newTestSessions builds the sessions from the environment. Setting
+// AWS_ENDPOINT_URL runs the tests against a LocalStack-style stand-in.
+func newTestSessions() (string, dbmesh.Sessions) {
 	region, _ := os.LookupEnv(EnvAWSRegion)
 	accessKey, _ := os.LookupEnv(EnvAWSAccessKey)
 	secretAccessKey, _ := os.LookupEnv(EnvAWSSecretAccessKey)
-	sess := dbmesh.NewSessions().SetCredential(region, accessKey, secretAccessKey).Build()
+	creds := dbmesh.NewSessions().SetCredential(region, accessKey, secretAccessKey)
+	if endpoint, ok := os.LookupEnv(EnvAWSEndpointURL); ok {
+		creds.SetEndpoint(endpoint).SetHostnameImmutable(true)
+	}
+	return region, creds.Build()
+}
+
+func Test_CreateRDSInstance(t *testing.T) {
+	region, sess := newTestSessions()
 	err := NewService(sess[region]).Instance().
 		SetEngine("mysql").
 		SetEngineVersion("8.0.28").
@@ -64,10 +75,7 @@ func Test_CreateRDSInstance(t *testing.T) {
 }
 
 func Test_CreateRDSInstanceWithMultiAZ(t *testing.T) {
-	region, _ := os.LookupEnv(EnvAWSRegion)
-	accessKey, _ := os.LookupEnv(EnvAWSAccessKey)
-	secretAccessKey, _ := os.LookupEnv(EnvAWSSecretAccessKey)
-	sess := dbmesh.NewSessions().SetCredential(region, accessKey, secretAccessKey).Build()
+	region, sess := newTestSessions()
 	err := NewService(sess[region]).Instance().
 		SetEngine("mysql").
 		SetEngineVersion("8.0.28").
@@ -89,10 +97,7 @@ func Test_CreateRDSInstanceWithMultiAZ(t *testing.T) {
 }
 
 func Test_DescribeRDSInstance(t *testing.T) {
-	region, _ := os.LookupEnv(EnvAWSRegion)
-	accessKey, _ := os.LookupEnv(EnvAWSAccessKey)
-	secretAccessKey, _ := os.LookupEnv(EnvAWSSecretAccessKey)
-	sess := dbmesh.NewSessions().SetCredential(region, accessKey, secretAccessKey).Build()
+	region, sess := newTestSessions()
 	output, err := NewService(sess[region]).Instance().
 		SetDBInstanceIdentifier(TestDBIdentifier).
 		Describe(context.TODO())
@@ -105,10 +110,7 @@ func Test_DescribeRDSInstance(t *testing.T) {
 }
 
 func Test_DeleteRDSInstance(t *testing.T) {
-	region, _ := os.LookupEnv(EnvAWSRegion)
-	accessKey, _ := os.LookupEnv(EnvAWSAccessKey)
-	secretAccessKey, _ := os.LookupEnv(EnvAWSSecretAccessKey)
-	sess := dbmesh.NewSessions().SetCredential(region, accessKey, secretAccessKey).Build()
+	region, sess := newTestSessions()
 	err := NewService(sess[region]).Instance().SetDBInstanceIdentifier("foo2-instance-1").SetSkipFinalSnapshot(true).SetDeleteAutomateBackups(false).Delete(context.TODO())
 	if err != nil {
 		t.Fatalf("%+v\n", err)
@@ -117,10 +119,7 @@ func Test_DeleteRDSInstance(t *testing.T) {
 }
 
 func Test_RebootRDSInstance(t *testing.T) {
-	region, _ := os.LookupEnv(EnvAWSRegion)
-	accessKey, _ := os.LookupEnv(EnvAWSAccessKey)
-	secretAccessKey, _ := os.LookupEnv(EnvAWSSecretAccessKey)
-	sess := dbmesh.NewSessions().SetCredential(region, accessKey, secretAccessKey).Build()
+	region, sess := newTestSessions()
 	err := NewService(sess[region]).Instance().SetDBInstanceIdentifier(TestDBIdentifier).SetForceFailover(true).Reboot(context.TODO())
 	if err != nil {
 		t.Fatalf("%+v\n", err)
@@ -129,10 +128,7 @@ func Test_RebootRDSInstance(t *testing.T) {
 }
 
 func Test_DescRDSInstance(t *testing.T) {
-	region, _ := os.LookupEnv(EnvAWSRegion)
-	accessKey, _ := os.LookupEnv(EnvAWSAccessKey)
-	secretAccessKey, _ := os.LookupEnv(EnvAWSSecretAccessKey)
-	sess := dbmesh.NewSessions().SetCredential(region, accessKey, secretAccessKey).Build()
+	region, sess := newTestSessions()
 	desc, err := NewService(sess[region]).Instance().SetDBInstanceIdentifier(TestDBIdentifier).Describe(context.TODO())
 	if err != nil {
 		t.Fatalf("%+v\n", err)
@@ -142,10 +138,7 @@ func Test_DescRDSInstance(t *testing.T) {
 }
 
 func Test_CreateRDSCluster(t *testing.T) {
-	region, _ := os.LookupEnv(EnvAWSRegion)
-	accessKey, _ := os.LookupEnv(EnvAWSAccessKey)
-	secretAccessKey, _ := os.LookupEnv(EnvAWSSecretAccessKey)
-	sess := dbmesh.NewSessions().SetCredential(region, accessKey, secretAccessKey).Build()
+	region, sess := newTestSessions()
 	err := NewService(sess[region]).Cluster().
 		SetEngine("mysql").
 		SetEngineVersion("8.0.28").
@@ -168,10 +161,7 @@ func Test_CreateRDSCluster(t *testing.T) {
 }
 
 func Test_DeleteRDSCluster(t *testing.T) {
-	region, _ := os.LookupEnv(EnvAWSRegion)
-	accessKey, _ := os.LookupEnv(EnvAWSAccessKey)
-	secretAccessKey, _ := os.LookupEnv(EnvAWSSecretAccessKey)
-	sess := dbmesh.NewSessions().SetCredential(region, accessKey, secretAccessKey).Build()
+	region, sess := newTestSessions()
 	err := NewService(sess[region]).Cluster().SetDBClusterIdentifier("foo2").SetSkipFinalSnapshot(true).Delete(context.TODO())
 	if err != nil {
 		t.Fatalf("%+v\n", err)
@@ -180,10 +170,7 @@ func Test_DeleteRDSCluster(t *testing.T) {
 }
 
 func Test_FailoverRDSCluster(t *testing.T) {
-	region, _ := os.LookupEnv(EnvAWSRegion)
-	accessKey, _ := os.LookupEnv(EnvAWSAccessKey)
-	secretAccessKey, _ := os.LookupEnv(EnvAWSSecretAccessKey)
-	sess := dbmesh.NewSessions().SetCredential(region, accessKey, secretAccessKey).Build()
+	region, sess := newTestSessions()
 	err := NewService(sess[region]).Cluster().SetDBClusterIdentifier(TestDBIdentifier).Failover(context.TODO())
 	if err != nil {
 		t.Fatalf("%+v\n", err)
@@ -192,10 +179,7 @@ func Test_FailoverRDSCluster(t *testing.T) {
 }
 
 func Test_DescribeRDSCluster(t *testing.T) {
-	region, _ := os.LookupEnv(EnvAWSRegion)
-	accessKey, _ := os.LookupEnv(EnvAWSAccessKey)
-	secretAccessKey, _ := os.LookupEnv(EnvAWSSecretAccessKey)
-	sess := dbmesh.NewSessions().SetCredential(region, accessKey, secretAccessKey).Build()
+	region, sess := newTestSessions()
 	output, err := NewService(sess[region]).Cluster().
 		SetDBClusterIdentifier("test").
 		Describe(context.TODO())
@@ -208,17 +192,12 @@ func Test_DescribeRDSCluster(t *testing.T) {
 }
 
 func Test_CreateRDSSubnetsGroup(t *testing.T) {
-	region, _ := os.LookupEnv(EnvAWSRegion)
-	accessKey, _ := os.LookupEnv(EnvAWSAccessKey)
-	secretAccessKey, _ := os.LookupEnv(EnvAWSSecretAccessKey)
-	sess := dbmesh.NewSessions().SetCredential(region, accessKey, secretAccessKey).Build()
-	client := NewService(sess[region]).cluster.core
-	snginput := &rds.CreateDBSubnetGroupInput{
-		SubnetIds:                []string{"subnet-gg", "subnet-gg", "subnet-gg"},
-		DBSubnetGroupName:        aws.String("test"),
-		DBSubnetGroupDescription: aws.String("test"),
-	}
-	_, err := client.CreateDBSubnetGroup(context.TODO(), snginput)
+	region, sess := newTestSessions()
+	err := NewService(sess[region]).SubnetGroup().
+		SetDBSubnetGroupName(TestSubnetGroup).
+		SetDescription("test").
+		SetSubnetIds([]string{"subnet-gg", "subnet-gg", "subnet-gg"}).
+		Create(context.TODO())
 	if err != nil {
 		t.Fatalf("%+v\n", err)
 	}
@@ -226,10 +205,7 @@ func Test_CreateRDSSubnetsGroup(t *testing.T) {
 }
 
 func Test_CreateRDSAurora(t *testing.T) {
-	region, _ := os.LookupEnv(EnvAWSRegion)
-	accessKey, _ := os.LookupEnv(EnvAWSAccessKey)
-	secretAccessKey, _ := os.LookupEnv(EnvAWSSecretAccessKey)
-	sess := dbmesh.NewSessions().SetCredential(region, accessKey, secretAccessKey).Build()
+	region, sess := newTestSessions()
 
 	err := NewService(sess[region]).Cluster().
 		SetEngine("aurora-mysql").
@@ -248,10 +224,7 @@ func Test_CreateRDSAurora(t *testing.T) {
 }
 
 func Test_CreateRDSInstanceForAurora(t *testing.T) {
-	region, _ := os.LookupEnv(EnvAWSRegion)
-	accessKey, _ := os.LookupEnv(EnvAWSAccessKey)
-	secretAccessKey, _ := os.LookupEnv(EnvAWSSecretAccessKey)
-	sess := dbmesh.NewSessions().SetCredential(region, accessKey, secretAccessKey).Build()
+	region, sess := newTestSessions()
 	err := NewService(sess[region]).Instance().
 		SetEngine("aurora-mysql").
 		SetDBInstanceIdentifier("foo2-instance-1").
@@ -268,15 +241,12 @@ func Test_CreateRDSInstanceForAurora(t *testing.T) {
 }
 
 func Test_DescribeRDSAurora(t *testing.T) {
-	region, _ := os.LookupEnv(EnvAWSRegion)
-	accessKey, _ := os.LookupEnv(EnvAWSAccessKey)
-	secretAccessKey, _ := os.LookupEnv(EnvAWSSecretAccessKey)
-	sess := dbmesh.NewSessions().SetCredential(region, accessKey, secretAccessKey).Build()
+	region, sess := newTestSessions()
 	input := &rds.DescribeDBClustersInput{
 		DBClusterIdentifier: aws.String(TestDBIdentifier),
 	}
 
-	output, err := NewService(sess[region]).cluster.core.DescribeDBClusters(context.TODO(), input)
+	output, err := NewService(sess[region]).core.DescribeDBClusters(context.TODO(), input)
 
 	if err != nil {
 		t.Fatalf("%+v\n", err)
@@ -294,10 +264,7 @@ func Test_DescribeRDSAurora(t *testing.T) {
 }
 
 func Test_CreateAuroraWithPrimary(t *testing.T) {
-	region, _ := os.LookupEnv(EnvAWSRegion)
-	accessKey, _ := os.LookupEnv(EnvAWSAccessKey)
-	secretAccessKey, _ := os.LookupEnv(EnvAWSSecretAccessKey)
-	sess := dbmesh.NewSessions().SetCredential(region, accessKey, secretAccessKey).Build()
+	region, sess := newTestSessions()
 
 	err := NewService(sess[region]).Aurora().
 		SetEngine("aurora-mysql").
@@ -319,10 +286,7 @@ func Test_CreateAuroraWithPrimary(t *testing.T) {
 }
 
 func Test_FailoverPrimary(t *testing.T) {
-	region, _ := os.LookupEnv(EnvAWSRegion)
-	accessKey, _ := os.LookupEnv(EnvAWSAccessKey)
-	secretAccessKey, _ := os.LookupEnv(EnvAWSSecretAccessKey)
-	sess := dbmesh.NewSessions().SetCredential(region, accessKey, secretAccessKey).Build()
+	region, sess := newTestSessions()
 
 	err := NewService(sess[region]).Aurora().
 		SetDBClusterIdentifier("test").
@@ -336,10 +300,7 @@ func Test_FailoverPrimary(t *testing.T) {
 }
 
 func Test_DeleteAurora(t *testing.T) {
-	region, _ := os.LookupEnv(EnvAWSRegion)
-	accessKey, _ := os.LookupEnv(EnvAWSAccessKey)
-	secretAccessKey, _ := os.LookupEnv(EnvAWSSecretAccessKey)
-	sess := dbmesh.NewSessions().SetCredential(region, accessKey, secretAccessKey).Build()
+	region, sess := newTestSessions()
 
 	err := NewService(sess[region]).Aurora().
 		SetDBInstanceIdentifier("foo-instance-1").
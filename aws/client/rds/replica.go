@@ -0,0 +1,129 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+// NOTE: A cross region replica needs the ARN of the source instance set by
+// SetSourceDBInstanceIdentifier and the region of the source set here.
+func (s *rdsInstance) SetSourceRegion(region string) Instance {
+	s.createReplicaParam.SourceRegion = aws.String(region)
+	return s
+}
+
+// NOTE: Required by cross region replicas of encrypted instances, the key
+// must belong to the region of the replica.
+func (s *rdsInstance) SetKmsKeyId(id string) Instance {
+	s.createInstanceParam.KmsKeyId = aws.String(id)
+	s.createReplicaParam.KmsKeyId = aws.String(id)
+	return s
+}
+
+// CreateReadReplica creates the instance set by SetDBInstanceIdentifier as a
+// read replica of the instance set by SetSourceDBInstanceIdentifier.
+func (s *rdsInstance) CreateReadReplica(ctx context.Context) error {
+	_, err := s.core.CreateDBInstanceReadReplica(ctx, s.createReplicaParam)
+	return err
+}
+
+// PromoteReadReplica turns the replica into a standalone instance. The
+// replication is stopped and the instance reboots, see WaitUntilAvailable.
+func (s *rdsInstance) PromoteReadReplica(ctx context.Context) error {
+	_, err := s.core.PromoteReadReplica(ctx, s.promoteReplicaParam)
+	return err
+}
+
+// ReplicaTopology is an instance and its read replicas.
+type ReplicaTopology struct {
+	// Primary is the topmost instance of this region, it is a replica itself
+	// when its source is in another region.
+	Primary *DescInstance
+	// Replicas are the replicas in this region, replicas of replicas included.
+	Replicas []*DescInstance
+	// CrossRegionReplicas are the ARNs of the replicas in other regions.
+	CrossRegionReplicas []string
+}
+
+// WriteEndpoint returns the endpoint of the primary.
+func (t *ReplicaTopology) WriteEndpoint() Endpoint {
+	return t.Primary.Endpoint
+}
+
+// ReadEndpoints returns the endpoints of the available replicas.
+func (t *ReplicaTopology) ReadEndpoints() []Endpoint {
+	endpoints := []Endpoint{}
+	for _, r := range t.Replicas {
+		if r.DBInstanceStatus == StatusAvailable {
+			endpoints = append(endpoints, r.Endpoint)
+		}
+	}
+	return endpoints
+}
+
+// ReplicaTopology describes the replication tree of the instance set by
+// SetDBInstanceIdentifier, which can be the primary or any of its replicas.
+func (s *rdsInstance) ReplicaTopology(ctx context.Context) (*ReplicaTopology, error) {
+	primary, err := s.describeInstance(ctx, aws.ToString(s.describeInstanceParam.DBInstanceIdentifier))
+	if err != nil {
+		return nil, err
+	}
+	for primary.ReadReplicaSourceDBInstanceIdentifier != "" && !arn.IsARN(primary.ReadReplicaSourceDBInstanceIdentifier) {
+		primary, err = s.describeInstance(ctx, primary.ReadReplicaSourceDBInstanceIdentifier)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	topology := &ReplicaTopology{Primary: primary}
+	pending := append([]string{}, primary.ReadReplicaDBInstanceIdentifiers...)
+	for len(pending) > 0 {
+		id := pending[0]
+		pending = pending[1:]
+		if arn.IsARN(id) {
+			topology.CrossRegionReplicas = append(topology.CrossRegionReplicas, id)
+			continue
+		}
+		replica, err := s.describeInstance(ctx, id)
+		if err != nil {
+			// NOTE: The replica may be deleted in the meantime.
+			if isInstanceNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		topology.Replicas = append(topology.Replicas, replica)
+		pending = append(pending, replica.ReadReplicaDBInstanceIdentifiers...)
+	}
+	return topology, nil
+}
+
+func (s *rdsInstance) describeInstance(ctx context.Context, id string) (*DescInstance, error) {
+	output, err := s.core.DescribeDBInstances(ctx, &rds.DescribeDBInstancesInput{DBInstanceIdentifier: aws.String(id)})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.DBInstances) == 0 {
+		return nil, &types.DBInstanceNotFoundFault{Message: aws.String(fmt.Sprintf("DBInstance %s not found.", id))}
+	}
+	return convertDBInstance(output.DBInstances[0]), nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/database-mesh/golang-sdk/aws/client/rds/fake"
+)
+
+func Test_ReadReplica(t *testing.T) {
+	f := fake.New().SetAutoAdvance(true)
+	svc := NewServiceWithAPI(f)
+
+	err := svc.Instance().
+		SetEngine("mysql").
+		SetDBInstanceIdentifier(TestDBIdentifier).
+		SetDBInstanceClass("db.m5.large").
+		SetAllocatedStorage(40).
+		Create(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := svc.Instance().SetDBInstanceIdentifier(TestDBIdentifier).WaitUntilAvailable(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	for _, id := range []string{"foo-replica-1", "foo-replica-2"} {
+		replica := svc.Instance().SetDBInstanceIdentifier(id).SetSourceDBInstanceIdentifier(TestDBIdentifier)
+		if err := replica.CreateReadReplica(context.TODO()); err != nil {
+			t.Fatalf("%+v\n", err)
+		}
+		if err := replica.WaitUntilAvailable(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+			t.Fatalf("%+v\n", err)
+		}
+	}
+
+	topology, err := svc.Instance().SetDBInstanceIdentifier("foo-replica-2").ReplicaTopology(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if topology.Primary.DBInstanceIdentifier != TestDBIdentifier || len(topology.Replicas) != 2 || len(topology.ReadEndpoints()) != 2 {
+		t.Fatalf("unexpected topology %#v\n", topology)
+	}
+
+	replica := svc.Instance().SetDBInstanceIdentifier("foo-replica-1").SetBackupRetentionPeriod(7)
+	if err := replica.PromoteReadReplica(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := replica.WaitUntilAvailable(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	desc, err := replica.Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if desc.ReadReplicaSourceDBInstanceIdentifier != "" {
+		t.Fatalf("unexpected instance %#v\n", desc)
+	}
+	desc, err = svc.Instance().SetDBInstanceIdentifier(TestDBIdentifier).Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(desc.ReadReplicaDBInstanceIdentifiers) != 1 || desc.ReadReplicaDBInstanceIdentifiers[0] != "foo-replica-2" {
+		t.Fatalf("unexpected instance %#v\n", desc)
+	}
+}
+
+func Test_ReadReplicaCrossRegion(t *testing.T) {
+	east := fake.New().SetAutoAdvance(true)
+	west := fake.New().SetRegion("us-west-2").SetAutoAdvance(true)
+	fake.Connect(east, west)
+
+	source := NewServiceWithAPI(east).Instance().
+		SetEngine("postgres").
+		SetDBInstanceIdentifier(TestDBIdentifier).
+		SetDBInstanceClass("db.m5.large").
+		SetKmsKeyId("alias/east")
+	if err := source.Create(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := source.WaitUntilAvailable(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	sdesc, err := source.Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+
+	replica := NewServiceWithAPI(west).Instance().
+		SetDBInstanceIdentifier("foo-replica").
+		SetSourceDBInstanceIdentifier(sdesc.DBInstanceArn).
+		SetSourceRegion("us-east-1")
+	if err := replica.CreateReadReplica(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	topology, err := source.ReplicaTopology(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(topology.Replicas) != 0 || len(topology.CrossRegionReplicas) != 1 {
+		t.Fatalf("unexpected topology %#v\n", topology)
+	}
+
+	if err := replica.WaitUntilAvailable(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := replica.PromoteReadReplica(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	sdesc, err = source.Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(sdesc.ReadReplicaDBInstanceIdentifiers) != 0 {
+		t.Fatalf("unexpected instance %#v\n", sdesc)
+	}
+}
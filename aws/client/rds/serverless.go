@@ -0,0 +1,164 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+const (
+	EngineModeProvisioned = "provisioned"
+	// EngineModeServerless is Aurora Serverless v1, Serverless v2 clusters
+	// are provisioned clusters of DBInstanceClassServerless instances.
+	EngineModeServerless = "serverless"
+
+	DBInstanceClassServerless = "db.serverless"
+)
+
+// ServerlessV2Scaling is the capacity range of Aurora Serverless v2, in Aurora
+// capacity units (ACU) from 0.5 to 128 by steps of 0.5.
+type ServerlessV2Scaling struct {
+	MinCapacity float64
+	MaxCapacity float64
+}
+
+// ServerlessV1Scaling is the capacity range and auto pause of Aurora
+// Serverless v1. The capacities are powers of 2, e.g. 1, 2, 4 up to 256
+// depending on the engine.
+type ServerlessV1Scaling struct {
+	MinCapacity int32
+	MaxCapacity int32
+	// AutoPause pauses the cluster after SecondsUntilAutoPause without
+	// connections.
+	AutoPause             bool
+	SecondsUntilAutoPause int32
+	// SecondsBeforeTimeout is how long to look for a scaling point, then
+	// TimeoutAction ForceApplyCapacityChange or RollbackCapacityChange.
+	SecondsBeforeTimeout int32
+	TimeoutAction        string
+}
+
+func (s ServerlessV2Scaling) configuration() *types.ServerlessV2ScalingConfiguration {
+	return &types.ServerlessV2ScalingConfiguration{
+		MinCapacity: aws.Float64(s.MinCapacity),
+		MaxCapacity: aws.Float64(s.MaxCapacity),
+	}
+}
+
+func (s ServerlessV1Scaling) configuration() *types.ScalingConfiguration {
+	c := &types.ScalingConfiguration{
+		MinCapacity: aws.Int32(s.MinCapacity),
+		MaxCapacity: aws.Int32(s.MaxCapacity),
+		AutoPause:   aws.Bool(s.AutoPause),
+	}
+	if s.SecondsUntilAutoPause != 0 {
+		c.SecondsUntilAutoPause = aws.Int32(s.SecondsUntilAutoPause)
+	}
+	if s.SecondsBeforeTimeout != 0 {
+		c.SecondsBeforeTimeout = aws.Int32(s.SecondsBeforeTimeout)
+	}
+	if s.TimeoutAction != "" {
+		c.TimeoutAction = aws.String(s.TimeoutAction)
+	}
+	return c
+}
+
+func convertServerlessScaling(desc *DescCluster, cluster types.DBCluster) {
+	if v2 := cluster.ServerlessV2ScalingConfiguration; v2 != nil {
+		desc.ServerlessV2Scaling = &ServerlessV2Scaling{
+			MinCapacity: aws.ToFloat64(v2.MinCapacity),
+			MaxCapacity: aws.ToFloat64(v2.MaxCapacity),
+		}
+	}
+	if v1 := cluster.ScalingConfigurationInfo; v1 != nil {
+		desc.ServerlessV1Scaling = &ServerlessV1Scaling{
+			MinCapacity:           aws.ToInt32(v1.MinCapacity),
+			MaxCapacity:           aws.ToInt32(v1.MaxCapacity),
+			AutoPause:             aws.ToBool(v1.AutoPause),
+			SecondsUntilAutoPause: aws.ToInt32(v1.SecondsUntilAutoPause),
+			SecondsBeforeTimeout:  aws.ToInt32(v1.SecondsBeforeTimeout),
+			TimeoutAction:         aws.ToString(v1.TimeoutAction),
+		}
+	}
+	desc.Capacity = aws.ToInt32(cluster.Capacity)
+}
+
+func (s *rdsCluster) SetServerlessV2Scaling(min, max float64) Cluster {
+	scaling := ServerlessV2Scaling{MinCapacity: min, MaxCapacity: max}
+	s.createClusterParam.ServerlessV2ScalingConfiguration = scaling.configuration()
+	s.modifyClusterParam.ServerlessV2ScalingConfiguration = scaling.configuration()
+	s.snapshot.restoreParam.ServerlessV2ScalingConfiguration = scaling.configuration()
+	return s
+}
+
+// NOTE: Serverless v1 also needs SetEngineMode(EngineModeServerless).
+func (s *rdsCluster) SetServerlessV1Scaling(scaling ServerlessV1Scaling) Cluster {
+	s.createClusterParam.ScalingConfiguration = scaling.configuration()
+	s.modifyClusterParam.ScalingConfiguration = scaling.configuration()
+	s.snapshot.restoreParam.ScalingConfiguration = scaling.configuration()
+	return s
+}
+
+func (s *rdsAurora) SetEngineMode(mode string) Aurora {
+	s.createClusterParam.EngineMode = aws.String(mode)
+	s.snapshot.restoreParam.EngineMode = aws.String(mode)
+	return s
+}
+
+// SetServerlessV2Scaling makes the cluster a Serverless v2 cluster, whose
+// instances default to DBInstanceClassServerless.
+func (s *rdsAurora) SetServerlessV2Scaling(min, max float64) Aurora {
+	scaling := ServerlessV2Scaling{MinCapacity: min, MaxCapacity: max}
+	s.createClusterParam.ServerlessV2ScalingConfiguration = scaling.configuration()
+	s.modifyClusterParam.ServerlessV2ScalingConfiguration = scaling.configuration()
+	s.snapshot.restoreParam.ServerlessV2ScalingConfiguration = scaling.configuration()
+	return s
+}
+
+// NOTE: Serverless v1 also needs SetEngineMode(EngineModeServerless), such
+// clusters have no instances so use Create instead of CreateWithPrimary.
+func (s *rdsAurora) SetServerlessV1Scaling(scaling ServerlessV1Scaling) Aurora {
+	s.createClusterParam.ScalingConfiguration = scaling.configuration()
+	s.modifyClusterParam.ScalingConfiguration = scaling.configuration()
+	s.snapshot.restoreParam.ScalingConfiguration = scaling.configuration()
+	return s
+}
+
+// ModifyScaling changes the capacity range set by SetServerlessV2Scaling or
+// SetServerlessV1Scaling, it is applied immediately. Other modifications set
+// on the builder are left to Modify.
+func (s *rdsAurora) ModifyScaling(ctx context.Context) error {
+	_, err := s.core.ModifyDBCluster(ctx, &rds.ModifyDBClusterInput{
+		DBClusterIdentifier:              s.modifyClusterParam.DBClusterIdentifier,
+		ServerlessV2ScalingConfiguration: s.modifyClusterParam.ServerlessV2ScalingConfiguration,
+		ScalingConfiguration:             s.modifyClusterParam.ScalingConfiguration,
+	})
+	return err
+}
+
+// primaryInstanceParam returns the parameters of the primary created along
+// with the cluster, Serverless v2 clusters default to
+// DBInstanceClassServerless.
+func (s *rdsAurora) primaryInstanceParam() *rds.CreateDBInstanceInput {
+	param := *s.createInstanceParam
+	if param.DBInstanceClass == nil && s.createClusterParam.ServerlessV2ScalingConfiguration != nil {
+		param.DBInstanceClass = aws.String(DBInstanceClassServerless)
+	}
+	return &param
+}
@@ -0,0 +1,105 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/database-mesh/golang-sdk/aws/client/rds/fake"
+)
+
+func Test_ServerlessV2(t *testing.T) {
+	f := fake.New().SetAutoAdvance(true)
+	svc := NewServiceWithAPI(f)
+	aurora := svc.Aurora().
+		SetEngine("aurora-mysql").
+		SetDBClusterIdentifier(TestDBIdentifier).
+		SetDBInstanceIdentifier("foo-instance-0").
+		SetMasterUsername("admin").
+		SetMasterUserPassword(TestDBPass).
+		SetServerlessV2Scaling(0.5, 8)
+	if err := aurora.CreateWithPrimary(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+
+	ins, err := svc.Instance().SetDBInstanceIdentifier("foo-instance-0").Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if ins.DBInstanceClass != DBInstanceClassServerless {
+		t.Fatalf("unexpected instance class %s\n", ins.DBInstanceClass)
+	}
+
+	// Only the scaling is modified, the missing parameter group is not sent.
+	aurora.SetDBClusterParameterGroupName("missing-pg")
+	if err := aurora.SetServerlessV2Scaling(1, 16).ModifyScaling(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	desc, err := aurora.Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if desc.ServerlessV2Scaling == nil || *desc.ServerlessV2Scaling != (ServerlessV2Scaling{MinCapacity: 1, MaxCapacity: 16}) {
+		t.Fatalf("unexpected scaling %#v\n", desc.ServerlessV2Scaling)
+	}
+
+	for _, scaling := range [][2]float64{{0, 8}, {1, 200}, {8, 1}, {1.25, 8}} {
+		if err := aurora.SetServerlessV2Scaling(scaling[0], scaling[1]).ModifyScaling(context.TODO()); err == nil {
+			t.Fatalf("expected invalid scaling error for %v\n", scaling)
+		}
+	}
+}
+
+func Test_ServerlessV1(t *testing.T) {
+	f := fake.New().SetAutoAdvance(true)
+	aurora := NewServiceWithAPI(f).Aurora().
+		SetEngine("aurora-mysql").
+		SetEngineMode(EngineModeServerless).
+		SetDBClusterIdentifier(TestDBIdentifier).
+		SetMasterUsername("admin").
+		SetMasterUserPassword(TestDBPass).
+		SetServerlessV1Scaling(ServerlessV1Scaling{MinCapacity: 2, MaxCapacity: 8, AutoPause: true})
+	if err := aurora.Create(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := aurora.WaitUntilAvailable(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := aurora.AddReaders(context.TODO(), ReaderSpec{DBInstanceClass: "db.r5.large"}); err == nil {
+		t.Fatalf("expected instance in serverless cluster error\n")
+	}
+
+	scaling := ServerlessV1Scaling{MinCapacity: 4, MaxCapacity: 16, SecondsUntilAutoPause: 600}
+	if err := aurora.SetServerlessV1Scaling(scaling).ModifyScaling(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	desc, err := aurora.Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	v1 := desc.ServerlessV1Scaling
+	if v1 == nil || v1.MinCapacity != 4 || v1.MaxCapacity != 16 || v1.AutoPause || v1.SecondsUntilAutoPause != 600 {
+		t.Fatalf("unexpected scaling %#v\n", v1)
+	}
+	if desc.Capacity != 4 {
+		t.Fatalf("unexpected capacity %d\n", desc.Capacity)
+	}
+
+	if err := aurora.SetServerlessV1Scaling(ServerlessV1Scaling{MinCapacity: 3, MaxCapacity: 16}).ModifyScaling(context.TODO()); err == nil {
+		t.Fatalf("expected invalid scaling error\n")
+	}
+}
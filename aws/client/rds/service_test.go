@@ -0,0 +1,163 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/database-mesh/golang-sdk/aws/client/rds/fake"
+)
+
+var _ API = fake.New()
+
+func Test_FakeInstanceLifecycle(t *testing.T) {
+	f := fake.New()
+	svc := NewServiceWithAPI(f)
+
+	err := svc.Instance().
+		SetEngine("mysql").
+		SetEngineVersion("8.0.28").
+		SetDBInstanceIdentifier(TestDBIdentifier).
+		SetDBInstanceClass("db.m5.large").
+		SetMasterUsername("admin").
+		SetMasterUserPassword(TestDBPass).
+		SetAllocatedStorage(40).
+		Create(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+
+	desc, err := svc.Instance().SetDBInstanceIdentifier(TestDBIdentifier).Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if desc.DBInstanceStatus != fake.StatusCreating {
+		t.Fatalf("expected %s, got %s\n", fake.StatusCreating, desc.DBInstanceStatus)
+	}
+
+	f.Advance()
+	desc, err = svc.Instance().SetDBInstanceIdentifier(TestDBIdentifier).Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if desc.DBInstanceStatus != fake.StatusAvailable || desc.Endpoint.Port != 3306 {
+		t.Fatalf("unexpected instance %#v\n", desc)
+	}
+
+	err = svc.Instance().SetDBInstanceIdentifier(TestDBIdentifier).SetSkipFinalSnapshot(true).Delete(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	f.Settle()
+
+	_, err = svc.Instance().SetDBInstanceIdentifier(TestDBIdentifier).Describe(context.TODO())
+	var notFound *types.DBInstanceNotFoundFault
+	if !errors.As(err, &notFound) {
+		t.Fatalf("expected DBInstanceNotFoundFault, got %+v\n", err)
+	}
+}
+
+func Test_FakeAuroraFailover(t *testing.T) {
+	f := fake.New()
+	svc := NewServiceWithAPI(f)
+
+	err := svc.Aurora().
+		SetEngine("aurora-mysql").
+		SetEngineVersion("5.7.mysql_aurora.2.07.0").
+		SetDBClusterIdentifier(TestDBIdentifier).
+		SetDBInstanceIdentifier("foo-instance-1").
+		SetDBInstanceClass("db.r5.large").
+		SetMasterUsername("admin").
+		SetMasterUserPassword(TestDBPass).
+		CreateWithPrimary(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	err = svc.Instance().
+		SetEngine("aurora-mysql").
+		SetDBInstanceIdentifier("foo-instance-2").
+		SetDBInstanceClass("db.r5.large").
+		SetDBClusterIdentifier(TestDBIdentifier).
+		Create(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	f.Settle()
+
+	if err := svc.Aurora().SetDBClusterIdentifier(TestDBIdentifier).FailoverPrimary(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	desc, err := svc.Cluster().SetDBClusterIdentifier(TestDBIdentifier).Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if desc.Status != fake.StatusFailingOver {
+		t.Fatalf("expected %s, got %s\n", fake.StatusFailingOver, desc.Status)
+	}
+	for _, m := range desc.DBClusterMembers {
+		if m.IsClusterWrite != (m.DBInstanceIdentifier == "foo-instance-2") {
+			t.Fatalf("unexpected members %#v\n", desc.DBClusterMembers)
+		}
+	}
+}
+
+func Test_FakeConcurrentBuilders(t *testing.T) {
+	f := fake.New()
+	var svc RDS = NewServiceWithAPI(f)
+
+	wg := sync.WaitGroup{}
+	errs := make(chan error, 16)
+	for n := 0; n < 16; n++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			errs <- svc.Instance().
+				SetEngine("mysql").
+				SetDBInstanceIdentifier(fmt.Sprintf("foo-%d", n)).
+				SetDBInstanceClass(fmt.Sprintf("db.m5.%dxlarge", n)).
+				SetAllocatedStorage(40).
+				Create(context.TODO())
+		}(n)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("%+v\n", err)
+		}
+	}
+
+	for n := 0; n < 16; n++ {
+		id := fmt.Sprintf("foo-%d", n)
+		desc, err := svc.Instance().SetDBInstanceIdentifier(id).Describe(context.TODO())
+		if err != nil {
+			t.Fatalf("%+v\n", err)
+		}
+		if desc.DBInstanceIdentifier != id {
+			t.Fatalf("expected %s, got %s\n", id, desc.DBInstanceIdentifier)
+		}
+	}
+
+	// A new builder does not inherit the state of a previous one.
+	err := svc.Instance().SetDBInstanceIdentifier("bar").Create(context.TODO())
+	if err == nil {
+		t.Fatalf("expected missing engine error\n")
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	dbmesh "github.com/database-mesh/golang-sdk/aws"
+)
+
+const describeDBInstancesResponse = `<DescribeDBInstancesResponse xmlns="http://rds.amazonaws.com/doc/2014-10-31/">
+  <DescribeDBInstancesResult>
+    <DBInstances>
+      <DBInstance>
+        <DBInstanceIdentifier>foo</DBInstanceIdentifier>
+        <DBInstanceStatus>available</DBInstanceStatus>
+        <Endpoint>
+          <Address>foo.local</Address>
+          <Port>3306</Port>
+        </Endpoint>
+      </DBInstance>
+    </DBInstances>
+  </DescribeDBInstancesResult>
+</DescribeDBInstancesResponse>`
+
+const throttlingResponse = `<ErrorResponse>
+  <Error>
+    <Type>Sender</Type>
+    <Code>Throttling</Code>
+    <Message>Rate exceeded</Message>
+  </Error>
+  <RequestId>test</RequestId>
+</ErrorResponse>`
+
+func Test_DescribeRDSInstanceWithCustomEndpoint(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("%+v\n", err)
+		}
+		if r.PostForm.Get("Action") != "DescribeDBInstances" || r.PostForm.Get("DBInstanceIdentifier") != TestDBIdentifier {
+			t.Errorf("unexpected request %+v\n", r.PostForm)
+		}
+		_, _ = w.Write([]byte(describeDBInstancesResponse))
+	}))
+	defer srv.Close()
+
+	sess, err := dbmesh.NewSessions().
+		SetCredential(TestAWSRegion, TestAWSAccessKey, TestAWSSecretAccessKey).
+		SetServiceEndpoint(rds.ServiceID, srv.URL).
+		SetHostnameImmutable(true).
+		SetInsecureSkipVerify(true).
+		BuildStrict()
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+
+	desc, err := NewService(sess[TestAWSRegion]).Instance().
+		SetDBInstanceIdentifier(TestDBIdentifier).
+		Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if desc.DBInstanceStatus != "available" || desc.Endpoint.Address != "foo.local" || desc.Endpoint.Port != 3306 {
+		t.Fatalf("unexpected instance %#v\n", desc)
+	}
+}
+
+func Test_DescribeRDSInstanceWithRetryAndRateLimit(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Throttle every other request.
+		if atomic.AddInt32(&calls, 1)%2 == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(throttlingResponse))
+			return
+		}
+		_, _ = w.Write([]byte(describeDBInstancesResponse))
+	}))
+	defer srv.Close()
+
+	sess, err := dbmesh.NewSessions().
+		SetCredential(TestAWSRegion, TestAWSAccessKey, TestAWSSecretAccessKey).
+		SetEndpoint(srv.URL).
+		SetMaxAttempts(2).
+		SetMaxBackoff(time.Millisecond).
+		SetRateLimit(20, 1).
+		BuildStrict()
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+
+	start := time.Now()
+	svc := NewService(sess[TestAWSRegion])
+	for i := 0; i < 2; i++ {
+		if _, err := svc.Instance().SetDBInstanceIdentifier(TestDBIdentifier).Describe(context.TODO()); err != nil {
+			t.Fatalf("%+v\n", err)
+		}
+	}
+
+	if calls != 4 {
+		t.Fatalf("expected 4 attempts, got %d\n", calls)
+	}
+	// 4 attempts at 20 per second with a burst of 1 take at least 150ms.
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Fatalf("requests were not rate limited, took %s\n", elapsed)
+	}
+}
+
+func Test_DescribeRDSInstanceWithZeroBurst(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(describeDBInstancesResponse))
+	}))
+	defer srv.Close()
+
+	sess, err := dbmesh.NewSessions().
+		SetCredential(TestAWSRegion, TestAWSAccessKey, TestAWSSecretAccessKey).
+		SetEndpoint(srv.URL).
+		SetRateLimit(0.5, 0).
+		BuildStrict()
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+
+	// The burst defaults to 1, so the first request is not delayed.
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+	if _, err := NewService(sess[TestAWSRegion]).Instance().SetDBInstanceIdentifier(TestDBIdentifier).Describe(ctx); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+}
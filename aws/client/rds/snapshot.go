@@ -0,0 +1,333 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+const (
+	SnapshotTypeManual    = "manual"
+	SnapshotTypeAutomated = "automated"
+	SnapshotTypeShared    = "shared"
+	SnapshotTypePublic    = "public"
+
+	// snapshotAttributeRestore holds the accounts allowed to restore a
+	// manual snapshot.
+	snapshotAttributeRestore = "restore"
+)
+
+// Snapshot manages manual snapshots of DB instances.
+type Snapshot interface {
+	SetDBSnapshotIdentifier(id string) Snapshot
+	SetDBInstanceIdentifier(id string) Snapshot
+	SetSnapshotType(t string) Snapshot
+	SetTags(tags map[string]string) Snapshot
+
+	// Copy
+	SetSourceDBSnapshotIdentifier(id string) Snapshot
+	SetSourceRegion(region string) Snapshot
+	SetKmsKeyId(id string) Snapshot
+	SetCopyTags(enable bool) Snapshot
+
+	// Restore
+	SetDBInstanceClass(class string) Snapshot
+	SetDBSubnetGroup(name string) Snapshot
+	SetVpcSecurityGroupIds(sgs []string) Snapshot
+	SetMultiAZ(enable bool) Snapshot
+	SetPublicAccessible(enable bool) Snapshot
+	SetDBParameterGroupName(name string) Snapshot
+
+	Create(context.Context) error
+	Describe(context.Context) (*DescSnapshot, error)
+	List(context.Context) ([]*DescSnapshot, error)
+	Copy(context.Context) error
+	Share(ctx context.Context, accounts ...string) error
+	Unshare(ctx context.Context, accounts ...string) error
+	Delete(context.Context) error
+	Restore(context.Context) error
+	Events(context.Context, *EventFilter) ([]*Event, error)
+
+	WaitUntilAvailable(context.Context, ...WaitOption) error
+	WaitUntilDeleted(context.Context, ...WaitOption) error
+}
+
+type rdsSnapshot struct {
+	core                 API
+	createParam          *rds.CreateDBSnapshotInput
+	describeParam        *rds.DescribeDBSnapshotsInput
+	copyParam            *rds.CopyDBSnapshotInput
+	deleteParam          *rds.DeleteDBSnapshotInput
+	restoreParam         *rds.RestoreDBInstanceFromDBSnapshotInput
+	modifyAttributeParam *rds.ModifyDBSnapshotAttributeInput
+}
+
+var _ Snapshot = &rdsSnapshot{}
+
+func newSnapshot(core API) *rdsSnapshot {
+	return &rdsSnapshot{
+		core:                 core,
+		createParam:          &rds.CreateDBSnapshotInput{},
+		describeParam:        &rds.DescribeDBSnapshotsInput{},
+		copyParam:            &rds.CopyDBSnapshotInput{},
+		deleteParam:          &rds.DeleteDBSnapshotInput{},
+		restoreParam:         &rds.RestoreDBInstanceFromDBSnapshotInput{},
+		modifyAttributeParam: &rds.ModifyDBSnapshotAttributeInput{AttributeName: aws.String(snapshotAttributeRestore)},
+	}
+}
+
+// SetDBSnapshotIdentifier names the snapshot to work on. For Copy it is the
+// name of the new snapshot.
+func (s *rdsSnapshot) SetDBSnapshotIdentifier(id string) Snapshot {
+	s.createParam.DBSnapshotIdentifier = aws.String(id)
+	s.describeParam.DBSnapshotIdentifier = aws.String(id)
+	s.copyParam.TargetDBSnapshotIdentifier = aws.String(id)
+	s.deleteParam.DBSnapshotIdentifier = aws.String(id)
+	s.restoreParam.DBSnapshotIdentifier = aws.String(id)
+	s.modifyAttributeParam.DBSnapshotIdentifier = aws.String(id)
+	return s
+}
+
+// SetDBInstanceIdentifier sets the instance to snapshot on Create, the
+// instance to list the snapshots of on List and the new instance on Restore.
+func (s *rdsSnapshot) SetDBInstanceIdentifier(id string) Snapshot {
+	s.createParam.DBInstanceIdentifier = aws.String(id)
+	s.describeParam.DBInstanceIdentifier = aws.String(id)
+	s.restoreParam.DBInstanceIdentifier = aws.String(id)
+	return s
+}
+
+func (s *rdsSnapshot) SetSnapshotType(t string) Snapshot {
+	s.describeParam.SnapshotType = aws.String(t)
+	return s
+}
+
+// SetSourceDBSnapshotIdentifier sets the snapshot to copy. It must be an
+// ARN when copying from another region.
+func (s *rdsSnapshot) SetSourceDBSnapshotIdentifier(id string) Snapshot {
+	s.copyParam.SourceDBSnapshotIdentifier = aws.String(id)
+	return s
+}
+
+// SetSourceRegion makes Copy a cross-region copy. The request is then
+// presigned for the source region by the SDK.
+func (s *rdsSnapshot) SetSourceRegion(region string) Snapshot {
+	s.copyParam.SourceRegion = aws.String(region)
+	return s
+}
+
+// NOTE: Required to copy an encrypted snapshot to another region, as KMS
+// keys are regional.
+func (s *rdsSnapshot) SetKmsKeyId(id string) Snapshot {
+	s.copyParam.KmsKeyId = aws.String(id)
+	return s
+}
+
+// SetTags sets the tags of the snapshot created by Create or Copy, and of
+// the instance created by Restore. Tags set several times are merged.
+func (s *rdsSnapshot) SetTags(tags map[string]string) Snapshot {
+	s.createParam.Tags = mergeTags(s.createParam.Tags, tags)
+	s.copyParam.Tags = mergeTags(s.copyParam.Tags, tags)
+	s.restoreParam.Tags = mergeTags(s.restoreParam.Tags, tags)
+	return s
+}
+
+func (s *rdsSnapshot) SetCopyTags(enable bool) Snapshot {
+	s.copyParam.CopyTags = aws.Bool(enable)
+	return s
+}
+
+func (s *rdsSnapshot) SetDBInstanceClass(class string) Snapshot {
+	s.restoreParam.DBInstanceClass = aws.String(class)
+	return s
+}
+
+func (s *rdsSnapshot) SetDBSubnetGroup(name string) Snapshot {
+	s.restoreParam.DBSubnetGroupName = aws.String(name)
+	return s
+}
+
+func (s *rdsSnapshot) SetVpcSecurityGroupIds(sgs []string) Snapshot {
+	s.restoreParam.VpcSecurityGroupIds = sgs
+	return s
+}
+
+func (s *rdsSnapshot) SetMultiAZ(enable bool) Snapshot {
+	s.restoreParam.MultiAZ = aws.Bool(enable)
+	return s
+}
+
+func (s *rdsSnapshot) SetPublicAccessible(enable bool) Snapshot {
+	s.restoreParam.PubliclyAccessible = aws.Bool(enable)
+	return s
+}
+
+func (s *rdsSnapshot) SetDBParameterGroupName(name string) Snapshot {
+	s.restoreParam.DBParameterGroupName = aws.String(name)
+	return s
+}
+
+func (s *rdsSnapshot) Create(ctx context.Context) error {
+	_, err := s.core.CreateDBSnapshot(ctx, s.createParam)
+	return err
+}
+
+type DescSnapshot struct {
+	DBSnapshotIdentifier       string
+	DBSnapshotArn              string
+	DBInstanceIdentifier       string
+	Engine                     string
+	EngineVersion              string
+	Status                     string
+	SnapshotType               string
+	SnapshotCreateTime         time.Time
+	AllocatedStorage           int32
+	Encrypted                  bool
+	KmsKeyId                   string
+	PercentProgress            int32
+	SourceDBSnapshotIdentifier string
+	SourceRegion               string
+	Tags                       map[string]string
+}
+
+// Describe returns the snapshot set by SetDBSnapshotIdentifier.
+func (s *rdsSnapshot) Describe(ctx context.Context) (*DescSnapshot, error) {
+	output, err := s.core.DescribeDBSnapshots(ctx, &rds.DescribeDBSnapshotsInput{
+		DBSnapshotIdentifier: s.describeParam.DBSnapshotIdentifier,
+	})
+	if err != nil {
+		return nil, err
+	}
+	desc := &DescSnapshot{}
+	if len(output.DBSnapshots) > 0 {
+		desc = convertDBSnapshot(output.DBSnapshots[0])
+	}
+	return desc, nil
+}
+
+// List returns the snapshots of the instance set by SetDBInstanceIdentifier,
+// or all of them, following pagination.
+func (s *rdsSnapshot) List(ctx context.Context) ([]*DescSnapshot, error) {
+	paginator := rds.NewDescribeDBSnapshotsPaginator(s.core, &rds.DescribeDBSnapshotsInput{
+		DBInstanceIdentifier: s.describeParam.DBInstanceIdentifier,
+		SnapshotType:         s.describeParam.SnapshotType,
+	})
+
+	descs := []*DescSnapshot{}
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, snapshot := range output.DBSnapshots {
+			descs = append(descs, convertDBSnapshot(snapshot))
+		}
+	}
+	return descs, nil
+}
+
+func convertDBSnapshot(snapshot types.DBSnapshot) *DescSnapshot {
+	return &DescSnapshot{
+		DBSnapshotIdentifier:       aws.ToString(snapshot.DBSnapshotIdentifier),
+		DBSnapshotArn:              aws.ToString(snapshot.DBSnapshotArn),
+		DBInstanceIdentifier:       aws.ToString(snapshot.DBInstanceIdentifier),
+		Engine:                     aws.ToString(snapshot.Engine),
+		EngineVersion:              aws.ToString(snapshot.EngineVersion),
+		Status:                     aws.ToString(snapshot.Status),
+		SnapshotType:               aws.ToString(snapshot.SnapshotType),
+		SnapshotCreateTime:         aws.ToTime(snapshot.SnapshotCreateTime),
+		AllocatedStorage:           snapshot.AllocatedStorage,
+		Encrypted:                  snapshot.Encrypted,
+		KmsKeyId:                   aws.ToString(snapshot.KmsKeyId),
+		PercentProgress:            snapshot.PercentProgress,
+		SourceDBSnapshotIdentifier: aws.ToString(snapshot.SourceDBSnapshotIdentifier),
+		SourceRegion:               aws.ToString(snapshot.SourceRegion),
+		Tags:                       convertTags(snapshot.TagList),
+	}
+}
+
+// Copy copies the snapshot set by SetSourceDBSnapshotIdentifier to the one
+// set by SetDBSnapshotIdentifier. The service must be the one of the target
+// region.
+func (s *rdsSnapshot) Copy(ctx context.Context) error {
+	_, err := s.core.CopyDBSnapshot(ctx, s.copyParam)
+	return err
+}
+
+// Share allows the accounts to restore the snapshot. Use "all" to make it
+// public.
+func (s *rdsSnapshot) Share(ctx context.Context, accounts ...string) error {
+	param := *s.modifyAttributeParam
+	param.ValuesToAdd = accounts
+	_, err := s.core.ModifyDBSnapshotAttribute(ctx, &param)
+	return err
+}
+
+// Unshare revokes the accounts given to Share.
+func (s *rdsSnapshot) Unshare(ctx context.Context, accounts ...string) error {
+	param := *s.modifyAttributeParam
+	param.ValuesToRemove = accounts
+	_, err := s.core.ModifyDBSnapshotAttribute(ctx, &param)
+	return err
+}
+
+func (s *rdsSnapshot) Delete(ctx context.Context) error {
+	_, err := s.core.DeleteDBSnapshot(ctx, s.deleteParam)
+	return err
+}
+
+// Restore creates the instance set by SetDBInstanceIdentifier from the
+// snapshot.
+func (s *rdsSnapshot) Restore(ctx context.Context) error {
+	_, err := s.core.RestoreDBInstanceFromDBSnapshot(ctx, s.restoreParam)
+	return err
+}
+
+func isSnapshotNotFound(err error) bool {
+	var notFound *types.DBSnapshotNotFoundFault
+	return errors.As(err, &notFound)
+}
+
+// WaitUntilAvailable waits for the snapshot to be available, e.g. after
+// Create or Copy.
+func (s *rdsSnapshot) WaitUntilAvailable(ctx context.Context, opts ...WaitOption) error {
+	return wait(ctx, func(ctx context.Context) (string, bool, error) {
+		desc, err := s.Describe(ctx)
+		if err != nil {
+			return "", false, err
+		}
+		return desc.Status, desc.Status == StatusAvailable, nil
+	}, opts...)
+}
+
+// WaitUntilDeleted waits for the snapshot to disappear after Delete.
+func (s *rdsSnapshot) WaitUntilDeleted(ctx context.Context, opts ...WaitOption) error {
+	return wait(ctx, func(ctx context.Context) (string, bool, error) {
+		desc, err := s.Describe(ctx)
+		if isSnapshotNotFound(err) {
+			return "deleted", true, nil
+		}
+		if err != nil {
+			return "", false, err
+		}
+		return desc.Status, false, nil
+	}, opts...)
+}
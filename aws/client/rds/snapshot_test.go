@@ -0,0 +1,139 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/database-mesh/golang-sdk/aws/client/rds/fake"
+)
+
+func Test_SnapshotLifecycle(t *testing.T) {
+	f := fake.New().SetAutoAdvance(true)
+	svc := NewServiceWithAPI(f)
+
+	err := svc.Instance().
+		SetEngine("mysql").
+		SetDBInstanceIdentifier(TestDBIdentifier).
+		SetDBInstanceClass("db.m5.large").
+		SetAllocatedStorage(40).
+		Create(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := svc.Instance().SetDBInstanceIdentifier(TestDBIdentifier).WaitUntilAvailable(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+
+	snapshot := svc.Snapshot().SetDBSnapshotIdentifier("foo-snap").SetDBInstanceIdentifier(TestDBIdentifier)
+	if err := snapshot.Create(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := snapshot.WaitUntilAvailable(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := snapshot.Share(context.TODO(), "210987654321"); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+
+	// Deleting with a final snapshot creates a second one.
+	err = svc.Instance().SetDBInstanceIdentifier(TestDBIdentifier).SetFinalDBSnapshotIdentifier("foo-final").Delete(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	descs, err := svc.Snapshot().SetDBInstanceIdentifier(TestDBIdentifier).List(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(descs) != 2 || descs[0].DBSnapshotIdentifier != "foo-final" {
+		t.Fatalf("unexpected snapshots %#v\n", descs)
+	}
+
+	restore := svc.Snapshot().SetDBSnapshotIdentifier("foo-snap").SetDBInstanceIdentifier("bar")
+	if err := restore.Restore(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	// The new instance of Restore does not filter the snapshot out.
+	snap, err := restore.Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if snap.DBSnapshotIdentifier != "foo-snap" || snap.DBInstanceIdentifier != TestDBIdentifier {
+		t.Fatalf("unexpected snapshot %#v\n", snap)
+	}
+	desc, err := svc.Instance().SetDBInstanceIdentifier("bar").Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if desc.DBInstanceClass != "db.m5.large" || desc.Engine != "mysql" {
+		t.Fatalf("unexpected instance %#v\n", desc)
+	}
+
+	if err := snapshot.Delete(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := snapshot.WaitUntilDeleted(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+}
+
+func Test_SnapshotCopyCrossRegion(t *testing.T) {
+	east := fake.New()
+	west := fake.New().SetRegion("us-west-2")
+	fake.Connect(east, west)
+
+	_, err := east.CreateDBInstance(context.TODO(), &rds.CreateDBInstanceInput{
+		DBInstanceIdentifier: aws.String(TestDBIdentifier),
+		DBInstanceClass:      aws.String("db.m5.large"),
+		Engine:               aws.String("postgres"),
+		StorageEncrypted:     aws.Bool(true),
+		KmsKeyId:             aws.String("alias/east"),
+	})
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	east.Settle()
+	source := NewServiceWithAPI(east).Snapshot().SetDBSnapshotIdentifier("foo-snap").SetDBInstanceIdentifier(TestDBIdentifier)
+	if err := source.Create(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	east.Settle()
+	sdesc, err := source.Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+
+	target := NewServiceWithAPI(west).Snapshot().
+		SetSourceDBSnapshotIdentifier(sdesc.DBSnapshotArn).
+		SetSourceRegion("us-east-1").
+		SetDBSnapshotIdentifier("foo-snap-copy")
+	if err := target.Copy(context.TODO()); err == nil {
+		t.Fatalf("expected KmsKeyId error\n")
+	}
+	if err := target.SetKmsKeyId("alias/west").Copy(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	desc, err := target.Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if desc.SourceRegion != "us-east-1" || desc.KmsKeyId != "alias/west" || desc.SourceDBSnapshotIdentifier != sdesc.DBSnapshotArn {
+		t.Fatalf("unexpected snapshot %#v\n", desc)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+)
+
+const (
+	StatusStopping = "stopping"
+	StatusStopped  = "stopped"
+	StatusStarting = "starting"
+)
+
+// AutoRestartAfter is how long RDS keeps a database stopped before starting
+// it again on its own, e.g. to apply maintenance. A schedule keeping a
+// database stopped for longer has to stop it again, see AutoRestartsWithin.
+const AutoRestartAfter = 7 * 24 * time.Hour
+
+// SetStopDBSnapshotIdentifier makes Stop take a snapshot of the instance
+// before stopping it.
+func (s *rdsInstance) SetStopDBSnapshotIdentifier(id string) Instance {
+	s.stopInstanceParam.DBSnapshotIdentifier = aws.String(id)
+	return s
+}
+
+// Stop stops the instance, which must be available. Instances of a DB
+// cluster are stopped with their cluster, and read replicas and their
+// sources cannot be stopped.
+func (s *rdsInstance) Stop(ctx context.Context) error {
+	_, err := s.core.StopDBInstance(ctx, s.stopInstanceParam)
+	return err
+}
+
+func (s *rdsInstance) Start(ctx context.Context) error {
+	_, err := s.core.StartDBInstance(ctx, s.startInstanceParam)
+	return err
+}
+
+// WaitUntilStopped waits for the instance to be stopped after Stop.
+func (s *rdsInstance) WaitUntilStopped(ctx context.Context, opts ...WaitOption) error {
+	return wait(ctx, func(ctx context.Context) (string, bool, error) {
+		desc, err := s.Describe(ctx)
+		if err != nil {
+			return "", false, err
+		}
+		return desc.DBInstanceStatus, desc.DBInstanceStatus == StatusStopped, nil
+	}, opts...)
+}
+
+// Stop stops the cluster and its instances.
+// NOTE: RDS takes no snapshot when stopping clusters, use CreateSnapshot and
+// WaitUntilSnapshotAvailable first to keep one.
+func (s *rdsCluster) Stop(ctx context.Context) error {
+	_, err := s.core.StopDBCluster(ctx, s.stopClusterParam)
+	return err
+}
+
+func (s *rdsCluster) Start(ctx context.Context) error {
+	_, err := s.core.StartDBCluster(ctx, s.startClusterParam)
+	return err
+}
+
+// WaitUntilStopped waits for the cluster to be stopped after Stop.
+func (s *rdsCluster) WaitUntilStopped(ctx context.Context, opts ...WaitOption) error {
+	return waitUntilClusterStopped(ctx, s.core, s.describeClusterParam, opts...)
+}
+
+// Stop stops the cluster and its instances. Aurora Serverless v1 clusters
+// and the members of global databases cannot be stopped.
+// NOTE: RDS takes no snapshot when stopping clusters, use CreateSnapshot and
+// WaitUntilSnapshotAvailable first to keep one.
+func (s *rdsAurora) Stop(ctx context.Context) error {
+	_, err := s.core.StopDBCluster(ctx, s.stopClusterParam)
+	return err
+}
+
+// Start starts the cluster and its instances, use WaitUntilAvailable to
+// wait for all of them.
+func (s *rdsAurora) Start(ctx context.Context) error {
+	_, err := s.core.StartDBCluster(ctx, s.startClusterParam)
+	return err
+}
+
+// WaitUntilStopped waits for the cluster to be stopped after Stop.
+func (s *rdsAurora) WaitUntilStopped(ctx context.Context, opts ...WaitOption) error {
+	return waitUntilClusterStopped(ctx, s.core, s.describeClusterParam, opts...)
+}
+
+func waitUntilClusterStopped(ctx context.Context, core API, param *rds.DescribeDBClustersInput, opts ...WaitOption) error {
+	return wait(ctx, func(ctx context.Context) (string, bool, error) {
+		desc, err := describeCluster(ctx, core, param)
+		if err != nil {
+			return "", false, err
+		}
+		return desc.Status, desc.Status == StatusStopped, nil
+	}, opts...)
+}
+
+// AutoRestartsWithin reports whether the instance is stopped and RDS starts
+// it again within the window.
+func (d *DescInstance) AutoRestartsWithin(window time.Duration) bool {
+	return autoRestartsWithin(d.DBInstanceStatus, d.AutomaticRestartTime, window)
+}
+
+// AutoRestartsWithin reports whether the cluster is stopped and RDS starts
+// it again within the window.
+func (d *DescCluster) AutoRestartsWithin(window time.Duration) bool {
+	return autoRestartsWithin(d.Status, d.AutomaticRestartTime, window)
+}
+
+func autoRestartsWithin(status string, restart time.Time, window time.Duration) bool {
+	if status != StatusStopped || restart.IsZero() {
+		return false
+	}
+	return time.Until(restart) <= window
+}
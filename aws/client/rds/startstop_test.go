@@ -0,0 +1,138 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/database-mesh/golang-sdk/aws/client/rds/fake"
+)
+
+func Test_StopStartInstance(t *testing.T) {
+	f := fake.New().SetAutoAdvance(true)
+	svc := NewServiceWithAPI(f)
+
+	instance := svc.Instance().
+		SetEngine("mysql").
+		SetEngineVersion("8.0.28").
+		SetDBInstanceIdentifier(TestDBIdentifier).
+		SetDBInstanceClass("db.m5.large").
+		SetAllocatedStorage(40).
+		SetStopDBSnapshotIdentifier("foo-stop")
+	if err := instance.Create(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := instance.WaitUntilAvailable(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := instance.Start(context.TODO()); err == nil {
+		t.Fatalf("expected invalid instance state error\n")
+	}
+
+	if err := instance.Stop(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := instance.WaitUntilStopped(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	desc, err := instance.Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if !desc.AutoRestartsWithin(AutoRestartAfter) || desc.AutoRestartsWithin(AutoRestartAfter-time.Hour) {
+		t.Fatalf("unexpected automatic restart time %s\n", desc.AutomaticRestartTime)
+	}
+	snapshot, err := svc.Snapshot().SetDBSnapshotIdentifier("foo-stop").Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if snapshot.DBInstanceIdentifier != TestDBIdentifier {
+		t.Fatalf("unexpected snapshot %#v\n", snapshot)
+	}
+
+	if err := instance.Start(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := instance.WaitUntilAvailable(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	desc, err = instance.Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if !desc.AutomaticRestartTime.IsZero() || desc.AutoRestartsWithin(AutoRestartAfter) {
+		t.Fatalf("unexpected automatic restart time %s\n", desc.AutomaticRestartTime)
+	}
+}
+
+func Test_StopStartAurora(t *testing.T) {
+	f := fake.New().SetAutoAdvance(true)
+	newAuroraWithReaders(t, f, 1)
+	svc := NewServiceWithAPI(f)
+	aurora := svc.Aurora().SetDBClusterIdentifier(TestDBIdentifier)
+
+	// Instances are stopped with their cluster.
+	if err := svc.Instance().SetDBInstanceIdentifier("foo-instance-1").Stop(context.TODO()); err == nil {
+		t.Fatalf("expected cluster instance stop error\n")
+	}
+	if err := aurora.Stop(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := aurora.WaitUntilStopped(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	desc, err := aurora.Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if !desc.AutoRestartsWithin(AutoRestartAfter) {
+		t.Fatalf("unexpected automatic restart time %s\n", desc.AutomaticRestartTime)
+	}
+	instances, err := svc.Instance().List(context.TODO(), &ListFilter{Statuses: []string{StatusStopped}})
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(instances) != 2 {
+		t.Fatalf("unexpected stopped instances %#v\n", instances)
+	}
+
+	// RDS starts the cluster again after seven days.
+	f.AutoRestart()
+	if err := aurora.WaitUntilAvailable(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	desc, err = aurora.Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if !desc.AutomaticRestartTime.IsZero() {
+		t.Fatalf("unexpected automatic restart time %s\n", desc.AutomaticRestartTime)
+	}
+
+	if err := aurora.Stop(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := aurora.WaitUntilStopped(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := aurora.Start(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := aurora.WaitUntilAvailable(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+}
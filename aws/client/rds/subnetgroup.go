@@ -0,0 +1,169 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+// SubnetGroup manages the DB subnet groups which place instances and
+// clusters in the subnets of a VPC.
+type SubnetGroup interface {
+	SetDBSubnetGroupName(name string) SubnetGroup
+	SetDescription(desc string) SubnetGroup
+	// SetSubnetIds sets the subnets of the group. RDS requires subnets of
+	// at least two availability zones of the same VPC.
+	SetSubnetIds(ids []string) SubnetGroup
+	SetTags(tags map[string]string) SubnetGroup
+
+	Create(context.Context) error
+	// Modify replaces the subnets, and the description when set, of the
+	// group.
+	Modify(context.Context) error
+	// Delete deletes the group, which must not be used by any database.
+	Delete(context.Context) error
+	Describe(context.Context) (*DescSubnetGroup, error)
+	List(context.Context) ([]*DescSubnetGroup, error)
+}
+
+type DescSubnetGroup struct {
+	DBSubnetGroupName        string
+	DBSubnetGroupArn         string
+	DBSubnetGroupDescription string
+	SubnetGroupStatus        string
+	VpcId                    string
+	Subnets                  []Subnet
+}
+
+type Subnet struct {
+	SubnetIdentifier string
+	AvailabilityZone string
+	SubnetStatus     string
+}
+
+type rdsSubnetGroup struct {
+	core API
+
+	createParam   *rds.CreateDBSubnetGroupInput
+	modifyParam   *rds.ModifyDBSubnetGroupInput
+	deleteParam   *rds.DeleteDBSubnetGroupInput
+	describeParam *rds.DescribeDBSubnetGroupsInput
+}
+
+func newSubnetGroup(core API) *rdsSubnetGroup {
+	return &rdsSubnetGroup{
+		core:          core,
+		createParam:   &rds.CreateDBSubnetGroupInput{},
+		modifyParam:   &rds.ModifyDBSubnetGroupInput{},
+		deleteParam:   &rds.DeleteDBSubnetGroupInput{},
+		describeParam: &rds.DescribeDBSubnetGroupsInput{},
+	}
+}
+
+func (s *rdsSubnetGroup) SetDBSubnetGroupName(name string) SubnetGroup {
+	s.createParam.DBSubnetGroupName = aws.String(name)
+	s.modifyParam.DBSubnetGroupName = aws.String(name)
+	s.deleteParam.DBSubnetGroupName = aws.String(name)
+	s.describeParam.DBSubnetGroupName = aws.String(name)
+	return s
+}
+
+func (s *rdsSubnetGroup) SetDescription(desc string) SubnetGroup {
+	s.createParam.DBSubnetGroupDescription = aws.String(desc)
+	s.modifyParam.DBSubnetGroupDescription = aws.String(desc)
+	return s
+}
+
+func (s *rdsSubnetGroup) SetSubnetIds(ids []string) SubnetGroup {
+	s.createParam.SubnetIds = ids
+	s.modifyParam.SubnetIds = ids
+	return s
+}
+
+// SetTags sets the tags of the group created by Create. Tags set several
+// times are merged.
+func (s *rdsSubnetGroup) SetTags(tags map[string]string) SubnetGroup {
+	s.createParam.Tags = mergeTags(s.createParam.Tags, tags)
+	return s
+}
+
+func (s *rdsSubnetGroup) Create(ctx context.Context) error {
+	_, err := s.core.CreateDBSubnetGroup(ctx, s.createParam)
+	return err
+}
+
+func (s *rdsSubnetGroup) Modify(ctx context.Context) error {
+	_, err := s.core.ModifyDBSubnetGroup(ctx, s.modifyParam)
+	return err
+}
+
+func (s *rdsSubnetGroup) Delete(ctx context.Context) error {
+	_, err := s.core.DeleteDBSubnetGroup(ctx, s.deleteParam)
+	return err
+}
+
+func (s *rdsSubnetGroup) Describe(ctx context.Context) (*DescSubnetGroup, error) {
+	output, err := s.core.DescribeDBSubnetGroups(ctx, s.describeParam)
+	if err != nil {
+		return nil, err
+	}
+	desc := &DescSubnetGroup{}
+	if len(output.DBSubnetGroups) > 0 {
+		desc = convertDBSubnetGroup(output.DBSubnetGroups[0])
+	}
+	return desc, nil
+}
+
+// List returns all subnet groups, following pagination.
+func (s *rdsSubnetGroup) List(ctx context.Context) ([]*DescSubnetGroup, error) {
+	paginator := rds.NewDescribeDBSubnetGroupsPaginator(s.core, &rds.DescribeDBSubnetGroupsInput{})
+
+	descs := []*DescSubnetGroup{}
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, sg := range output.DBSubnetGroups {
+			descs = append(descs, convertDBSubnetGroup(sg))
+		}
+	}
+	return descs, nil
+}
+
+func convertDBSubnetGroup(sg types.DBSubnetGroup) *DescSubnetGroup {
+	desc := &DescSubnetGroup{
+		DBSubnetGroupName:        aws.ToString(sg.DBSubnetGroupName),
+		DBSubnetGroupArn:         aws.ToString(sg.DBSubnetGroupArn),
+		DBSubnetGroupDescription: aws.ToString(sg.DBSubnetGroupDescription),
+		SubnetGroupStatus:        aws.ToString(sg.SubnetGroupStatus),
+		VpcId:                    aws.ToString(sg.VpcId),
+	}
+	for _, s := range sg.Subnets {
+		subnet := Subnet{
+			SubnetIdentifier: aws.ToString(s.SubnetIdentifier),
+			SubnetStatus:     aws.ToString(s.SubnetStatus),
+		}
+		if s.SubnetAvailabilityZone != nil {
+			subnet.AvailabilityZone = aws.ToString(s.SubnetAvailabilityZone.Name)
+		}
+		desc.Subnets = append(desc.Subnets, subnet)
+	}
+	return desc
+}
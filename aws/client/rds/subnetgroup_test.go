@@ -0,0 +1,85 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"testing"
+
+	"github.com/database-mesh/golang-sdk/aws/client/rds/fake"
+)
+
+func Test_SubnetGroup(t *testing.T) {
+	f := fake.New()
+	svc := NewServiceWithAPI(f)
+
+	sg := svc.SubnetGroup().
+		SetDBSubnetGroupName(TestSubnetGroup).
+		SetDescription("test").
+		SetSubnetIds([]string{"subnet-a", "subnet-b", "subnet-b"})
+	if err := sg.Create(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := sg.Create(context.TODO()); err == nil {
+		t.Fatalf("expected subnet group already exists error\n")
+	}
+	desc, err := sg.Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if desc.VpcId != fake.FakeVpcID || len(desc.Subnets) != 2 || desc.DBSubnetGroupDescription != "test" {
+		t.Fatalf("unexpected subnet group %#v\n", desc)
+	}
+
+	err = sg.SetDescription("updated").SetSubnetIds([]string{"subnet-a", "subnet-b", "subnet-c"}).Modify(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	descs, err := svc.SubnetGroup().List(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(descs) != 1 || len(descs[0].Subnets) != 3 || descs[0].DBSubnetGroupDescription != "updated" {
+		t.Fatalf("unexpected subnet groups %#v\n", descs)
+	}
+
+	err = svc.Cluster().
+		SetEngine("aurora-mysql").
+		SetDBClusterIdentifier(TestDBIdentifier).
+		SetMasterUsername("admin").
+		SetMasterUserPassword(TestDBPass).
+		SetDBSubnetGroupName(TestSubnetGroup).
+		Create(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	f.Settle()
+
+	// Groups in use cannot be deleted.
+	if err := sg.Delete(context.TODO()); err == nil {
+		t.Fatalf("expected subnet group in use error\n")
+	}
+	err = svc.Cluster().SetDBClusterIdentifier(TestDBIdentifier).SetSkipFinalSnapshot(true).Delete(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	f.Settle()
+	if err := sg.Delete(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if _, err := sg.Describe(context.TODO()); err == nil {
+		t.Fatalf("expected subnet group not found error\n")
+	}
+}
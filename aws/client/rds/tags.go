@@ -0,0 +1,121 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Ownership tags link the RDS resources to the Kubernetes object they were
+// provisioned for, see OwnershipTags.
+const (
+	TagOwnerNamespace = "database-mesh.io/owner-namespace"
+	TagOwnerName      = "database-mesh.io/owner-name"
+	TagOwnerUID       = "database-mesh.io/owner-uid"
+	TagDatabaseClass  = "database-mesh.io/databaseclass"
+)
+
+// Tagging manages the tags of any RDS resource by ARN.
+type Tagging interface {
+	// AddTags adds the tags to the resource, overwriting the values of
+	// existing keys.
+	AddTags(ctx context.Context, arn string, tags map[string]string) error
+	RemoveTags(ctx context.Context, arn string, keys ...string) error
+	ListTags(ctx context.Context, arn string) (map[string]string, error)
+}
+
+type rdsTagging struct {
+	core API
+}
+
+func newTagging(core API) *rdsTagging {
+	return &rdsTagging{core: core}
+}
+
+func (s *rdsTagging) AddTags(ctx context.Context, arn string, tags map[string]string) error {
+	_, err := s.core.AddTagsToResource(ctx, &rds.AddTagsToResourceInput{
+		ResourceName: aws.String(arn),
+		Tags:         mergeTags(nil, tags),
+	})
+	return err
+}
+
+func (s *rdsTagging) RemoveTags(ctx context.Context, arn string, keys ...string) error {
+	_, err := s.core.RemoveTagsFromResource(ctx, &rds.RemoveTagsFromResourceInput{
+		ResourceName: aws.String(arn),
+		TagKeys:      keys,
+	})
+	return err
+}
+
+func (s *rdsTagging) ListTags(ctx context.Context, arn string) (map[string]string, error) {
+	output, err := s.core.ListTagsForResource(ctx, &rds.ListTagsForResourceInput{
+		ResourceName: aws.String(arn),
+	})
+	if err != nil {
+		return nil, err
+	}
+	tags := convertTags(output.TagList)
+	if tags == nil {
+		tags = map[string]string{}
+	}
+	return tags, nil
+}
+
+// OwnershipTags returns the tags naming the owner, e.g. the object a
+// DatabaseClass is provisioned for, and the DatabaseClass when set.
+func OwnershipTags(owner metav1.Object, databaseClass string) map[string]string {
+	tags := map[string]string{
+		TagOwnerNamespace: owner.GetNamespace(),
+		TagOwnerName:      owner.GetName(),
+		TagOwnerUID:       string(owner.GetUID()),
+	}
+	if databaseClass != "" {
+		tags[TagDatabaseClass] = databaseClass
+	}
+	return tags
+}
+
+// mergeTags sets the tags on top of the current ones. The tags are sorted by
+// key to keep the requests stable.
+func mergeTags(current []types.Tag, tags map[string]string) []types.Tag {
+	if len(current) == 0 && len(tags) == 0 {
+		return current
+	}
+	merged := convertTags(current)
+	if merged == nil {
+		merged = map[string]string{}
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+
+	keys := make([]string, 0, len(merged))
+	for k := range merged {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	list := make([]types.Tag, 0, len(keys))
+	for _, k := range keys {
+		list = append(list, types.Tag{Key: aws.String(k), Value: aws.String(merged[k])})
+	}
+	return list
+}
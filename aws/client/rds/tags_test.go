@@ -0,0 +1,112 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/database-mesh/golang-sdk/aws/client/rds/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_OwnershipTags(t *testing.T) {
+	f := fake.New().SetAutoAdvance(true)
+	owner := &metav1.ObjectMeta{Namespace: "default", Name: "foo", UID: "8a6f0a2c-1f0e-4c1a-9d9b-0e1d2c3b4a59"}
+	svc := NewServiceWithAPI(f).WithOwner(owner, "aurora-mysql")
+	expected := map[string]string{
+		TagOwnerNamespace: "default",
+		TagOwnerName:      "foo",
+		TagOwnerUID:       "8a6f0a2c-1f0e-4c1a-9d9b-0e1d2c3b4a59",
+		TagDatabaseClass:  "aurora-mysql",
+	}
+
+	aurora := svc.Aurora().
+		SetEngine("aurora-mysql").
+		SetDBClusterIdentifier(TestDBIdentifier).
+		SetDBInstanceIdentifier("foo-instance-0").
+		SetDBInstanceClass("db.r5.large").
+		SetMasterUsername("admin").
+		SetMasterUserPassword(TestDBPass).
+		SetTags(map[string]string{"team": "dba"})
+	if err := aurora.CreateWithPrimary(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := aurora.WaitUntilAvailable(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := aurora.AddReaders(context.TODO(), ReaderSpec{}); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	f.Settle()
+
+	expected["team"] = "dba"
+	instances, err := svc.Instance().List(context.TODO(), &ListFilter{Tags: map[string]string{TagOwnerUID: string(owner.UID)}})
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(instances) != 2 {
+		t.Fatalf("unexpected instances %#v\n", instances)
+	}
+	for _, i := range instances {
+		if !reflect.DeepEqual(i.Tags, expected) {
+			t.Fatalf("unexpected tags %#v\n", i.Tags)
+		}
+	}
+	desc, err := svc.Cluster().SetDBClusterIdentifier(TestDBIdentifier).Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if !reflect.DeepEqual(desc.Tags, expected) {
+		t.Fatalf("unexpected tags %#v\n", desc.Tags)
+	}
+	delete(expected, "team")
+
+	// Resources whose description has no tags are listed by ARN.
+	if err := svc.ParameterGroup().SetDBParameterGroupName("foo-pg").SetDBParameterGroupFamily("mysql8.0").SetDescription("foo").Create(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	pg, err := svc.ParameterGroup().SetDBParameterGroupName("foo-pg").Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	tagging := svc.Tagging()
+	tags, err := tagging.ListTags(context.TODO(), pg.DBParameterGroupArn)
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if !reflect.DeepEqual(tags, expected) {
+		t.Fatalf("unexpected tags %#v\n", tags)
+	}
+
+	if err := tagging.AddTags(context.TODO(), desc.DBClusterArn, map[string]string{"team": "sre", "env": "test"}); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := tagging.RemoveTags(context.TODO(), desc.DBClusterArn, TagDatabaseClass); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	tags, err = tagging.ListTags(context.TODO(), desc.DBClusterArn)
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(tags) != 5 || tags["team"] != "sre" || tags["env"] != "test" || tags[TagDatabaseClass] != "" {
+		t.Fatalf("unexpected tags %#v\n", tags)
+	}
+	if _, err := tagging.ListTags(context.TODO(), "arn:aws:rds:us-east-1:000000000000:db:bar"); err == nil {
+		t.Fatalf("expected resource not found error\n")
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+const (
+	DefaultWaitPollInterval = 30 * time.Second
+	DefaultWaitTimeout      = 60 * time.Minute
+)
+
+const (
+	StatusAvailable   = "available"
+	StatusFailingOver = "failing-over"
+)
+
+var (
+	ErrWaitTimeout      = errors.New("timed out waiting")
+	ErrUnexpectedStatus = errors.New("unexpected status")
+)
+
+// failedStatuses are the statuses a database does not leave on its own.
+var failedStatuses = map[string]struct{}{
+	"failed":                              {},
+	"incompatible-network":                {},
+	"incompatible-option-group":           {},
+	"incompatible-parameters":             {},
+	"incompatible-restore":                {},
+	"inaccessible-encryption-credentials": {},
+	"restore-error":                       {},
+	"storage-full":                        {},
+}
+
+// WaitProgress is reported after every poll of a waiter.
+type WaitProgress struct {
+	Attempt int
+	Status  string
+	Elapsed time.Duration
+}
+
+type waitOptions struct {
+	pollInterval time.Duration
+	timeout      time.Duration
+	onProgress   func(WaitProgress)
+}
+
+type WaitOption func(*waitOptions)
+
+// WithPollInterval sets the delay between two polls, DefaultWaitPollInterval
+// by default. Intervals of 0 or less are ignored.
+func WithPollInterval(d time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		if d > 0 {
+			o.pollInterval = d
+		}
+	}
+}
+
+// WithTimeout sets how long to wait at most, DefaultWaitTimeout by default.
+// The context may end the wait earlier. Timeouts of 0 or less are ignored.
+func WithTimeout(d time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		if d > 0 {
+			o.timeout = d
+		}
+	}
+}
+
+// WithProgress registers a callback invoked after every poll.
+func WithProgress(fn func(WaitProgress)) WaitOption {
+	return func(o *waitOptions) {
+		o.onProgress = fn
+	}
+}
+
+// waitCheck polls the resource once and reports its status and whether the
+// wait is over.
+type waitCheck func(ctx context.Context) (status string, done bool, err error)
+
+func wait(ctx context.Context, check waitCheck, opts ...WaitOption) error {
+	o := &waitOptions{
+		pollInterval: DefaultWaitPollInterval,
+		timeout:      DefaultWaitTimeout,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	start := time.Now()
+	deadline := time.NewTimer(o.timeout)
+	defer deadline.Stop()
+
+	status := ""
+	for attempt := 1; ; attempt++ {
+		var (
+			done bool
+			err  error
+		)
+		status, done, err = check(ctx)
+		if err != nil {
+			return err
+		}
+		if o.onProgress != nil {
+			o.onProgress(WaitProgress{Attempt: attempt, Status: status, Elapsed: time.Since(start)})
+		}
+		if done {
+			return nil
+		}
+		if _, ok := failedStatuses[status]; ok {
+			return fmt.Errorf("%w: %s", ErrUnexpectedStatus, status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline.C:
+			return fmt.Errorf("%w after %s, last status %q", ErrWaitTimeout, o.timeout, status)
+		case <-time.After(o.pollInterval):
+		}
+	}
+}
+
+func isInstanceNotFound(err error) bool {
+	var notFound *types.DBInstanceNotFoundFault
+	return errors.As(err, &notFound)
+}
+
+func isClusterNotFound(err error) bool {
+	var notFound *types.DBClusterNotFoundFault
+	return errors.As(err, &notFound)
+}
+
+// WaitUntilAvailable waits for the instance to be available, e.g. after
+// Create, Reboot or RestorePitr.
+func (s *rdsInstance) WaitUntilAvailable(ctx context.Context, opts ...WaitOption) error {
+	return wait(ctx, func(ctx context.Context) (string, bool, error) {
+		desc, err := s.Describe(ctx)
+		if err != nil {
+			return "", false, err
+		}
+		return desc.DBInstanceStatus, desc.DBInstanceStatus == StatusAvailable, nil
+	}, opts...)
+}
+
+// WaitUntilDeleted waits for the instance to disappear after Delete.
+func (s *rdsInstance) WaitUntilDeleted(ctx context.Context, opts ...WaitOption) error {
+	return wait(ctx, func(ctx context.Context) (string, bool, error) {
+		desc, err := s.Describe(ctx)
+		if isInstanceNotFound(err) {
+			return "deleted", true, nil
+		}
+		if err != nil {
+			return "", false, err
+		}
+		return desc.DBInstanceStatus, false, nil
+	}, opts...)
+}
+
+// WaitUntilModified waits for the instance to be available with no
+// modification left pending. Modifications deferred to the maintenance
+// window keep it waiting until then.
+func (s *rdsInstance) WaitUntilModified(ctx context.Context, opts ...WaitOption) error {
+	return wait(ctx, func(ctx context.Context) (string, bool, error) {
+		desc, err := s.Describe(ctx)
+		if err != nil {
+			return "", false, err
+		}
+		return desc.DBInstanceStatus, desc.DBInstanceStatus == StatusAvailable && !desc.PendingModifications, nil
+	}, opts...)
+}
+
+func waitUntilClusterAvailable(ctx context.Context, core API, param *rds.DescribeDBClustersInput, opts ...WaitOption) error {
+	return wait(ctx, func(ctx context.Context) (string, bool, error) {
+		desc, err := describeCluster(ctx, core, param)
+		if err != nil {
+			return "", false, err
+		}
+		return desc.Status, desc.Status == StatusAvailable, nil
+	}, opts...)
+}
+
+func waitUntilClusterDeleted(ctx context.Context, core API, param *rds.DescribeDBClustersInput, opts ...WaitOption) error {
+	return wait(ctx, func(ctx context.Context) (string, bool, error) {
+		desc, err := describeCluster(ctx, core, param)
+		if isClusterNotFound(err) {
+			return "deleted", true, nil
+		}
+		if err != nil {
+			return "", false, err
+		}
+		return desc.Status, false, nil
+	}, opts...)
+}
+
+// waitUntilClusterFailoverComplete waits for the cluster to be available
+// again, with a writer other than from and with target as its writer, unless
+// they are empty. The cluster is usually still available with the old writer
+// right after FailoverDBCluster returns.
+func waitUntilClusterFailoverComplete(ctx context.Context, core API, param *rds.DescribeDBClustersInput, from, target string, opts ...WaitOption) error {
+	return wait(ctx, func(ctx context.Context) (string, bool, error) {
+		desc, err := describeCluster(ctx, core, param)
+		if err != nil {
+			return "", false, err
+		}
+		writer := desc.Writer()
+		done := desc.Status == StatusAvailable && (from == "" || writer != from) && (target == "" || writer == target)
+		return desc.Status, done, nil
+	}, opts...)
+}
+
+// WaitUntilAvailable waits for the cluster to be available, e.g. after
+// Create, Reboot or RestorePitr.
+func (s *rdsCluster) WaitUntilAvailable(ctx context.Context, opts ...WaitOption) error {
+	return waitUntilClusterAvailable(ctx, s.core, s.describeClusterParam, opts...)
+}
+
+// WaitUntilDeleted waits for the cluster to disappear after Delete.
+func (s *rdsCluster) WaitUntilDeleted(ctx context.Context, opts ...WaitOption) error {
+	return waitUntilClusterDeleted(ctx, s.core, s.describeClusterParam, opts...)
+}
+
+// WaitUntilFailoverComplete waits for the cluster to be available after
+// Failover with a new writer, the target instance if one was set.
+func (s *rdsCluster) WaitUntilFailoverComplete(ctx context.Context, opts ...WaitOption) error {
+	target := aws.ToString(s.failoverClusterParam.TargetDBInstanceIdentifier)
+	return waitUntilClusterFailoverComplete(ctx, s.core, s.describeClusterParam, s.failoverFrom, target, opts...)
+}
+
+// WaitUntilModified waits for the cluster to be available with no
+// modification left pending.
+func (s *rdsCluster) WaitUntilModified(ctx context.Context, opts ...WaitOption) error {
+	return wait(ctx, func(ctx context.Context) (string, bool, error) {
+		desc, err := s.Describe(ctx)
+		if err != nil {
+			return "", false, err
+		}
+		return desc.Status, desc.Status == StatusAvailable && !desc.PendingModifications, nil
+	}, opts...)
+}
+
+// WaitUntilAvailable waits for the cluster and all of its instances to be
+// available, e.g. after CreateWithPrimary.
+func (s *rdsAurora) WaitUntilAvailable(ctx context.Context, opts ...WaitOption) error {
+	return wait(ctx, func(ctx context.Context) (string, bool, error) {
+		desc, err := describeCluster(ctx, s.core, s.describeClusterParam)
+		if err != nil {
+			return "", false, err
+		}
+		if desc.Status != StatusAvailable {
+			return desc.Status, false, nil
+		}
+
+		instances, err := describeClusterInstances(ctx, s.core, desc.DBClusterIdentifier)
+		if err != nil {
+			return "", false, err
+		}
+		for _, i := range instances {
+			id, status := aws.ToString(i.DBInstanceIdentifier), aws.ToString(i.DBInstanceStatus)
+			// The status of a member is reported with its identifier, which
+			// wait cannot match against failedStatuses.
+			if _, ok := failedStatuses[status]; ok {
+				return "", false, fmt.Errorf("%w: %s: %s", ErrUnexpectedStatus, id, status)
+			}
+			if status != StatusAvailable {
+				return fmt.Sprintf("%s: %s", id, status), false, nil
+			}
+		}
+		return desc.Status, true, nil
+	}, opts...)
+}
+
+// WaitUntilDeleted waits for the cluster to disappear after Delete.
+func (s *rdsAurora) WaitUntilDeleted(ctx context.Context, opts ...WaitOption) error {
+	return waitUntilClusterDeleted(ctx, s.core, s.describeClusterParam, opts...)
+}
+
+// WaitUntilFailoverComplete waits for the cluster to be available with a new
+// writer after FailoverPrimary, or for the picked reader to be the writer
+// after FailoverRandomOneReadonlyEndpoint.
+func (s *rdsAurora) WaitUntilFailoverComplete(ctx context.Context, opts ...WaitOption) error {
+	target := aws.ToString(s.failoverTarget)
+	return waitUntilClusterFailoverComplete(ctx, s.core, s.describeClusterParam, s.failoverFrom, target, opts...)
+}
@@ -0,0 +1,318 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rds
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/database-mesh/golang-sdk/aws/client/rds/fake"
+)
+
+func Test_WaitInstance(t *testing.T) {
+	f := fake.New().SetAutoAdvance(true)
+	svc := NewServiceWithAPI(f)
+
+	err := svc.Instance().
+		SetEngine("mysql").
+		SetDBInstanceIdentifier(TestDBIdentifier).
+		SetDBInstanceClass("db.m5.large").
+		SetAllocatedStorage(40).
+		Create(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+
+	var progress []WaitProgress
+	err = svc.Instance().SetDBInstanceIdentifier(TestDBIdentifier).WaitUntilAvailable(context.TODO(),
+		WithPollInterval(time.Millisecond),
+		WithProgress(func(p WaitProgress) { progress = append(progress, p) }),
+	)
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if len(progress) == 0 || progress[len(progress)-1].Status != StatusAvailable {
+		t.Fatalf("unexpected progress %#v\n", progress)
+	}
+
+	err = svc.Instance().SetDBInstanceIdentifier(TestDBIdentifier).SetSkipFinalSnapshot(true).Delete(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	err = svc.Instance().SetDBInstanceIdentifier(TestDBIdentifier).WaitUntilDeleted(context.TODO(), WithPollInterval(time.Millisecond))
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+}
+
+func Test_WaitTimeout(t *testing.T) {
+	f := fake.New()
+	svc := NewServiceWithAPI(f)
+
+	err := svc.Instance().
+		SetEngine("mysql").
+		SetDBInstanceIdentifier(TestDBIdentifier).
+		SetDBInstanceClass("db.m5.large").
+		SetAllocatedStorage(40).
+		Create(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+
+	err = svc.Instance().SetDBInstanceIdentifier(TestDBIdentifier).WaitUntilAvailable(context.TODO(),
+		WithPollInterval(time.Millisecond),
+		WithTimeout(20*time.Millisecond),
+	)
+	if !errors.Is(err, ErrWaitTimeout) {
+		t.Fatalf("expected ErrWaitTimeout, got %+v\n", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	err = svc.Instance().SetDBInstanceIdentifier(TestDBIdentifier).WaitUntilAvailable(ctx, WithPollInterval(time.Millisecond))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %+v\n", err)
+	}
+}
+
+func Test_WaitIgnoresNonPositiveOptions(t *testing.T) {
+	f := fake.New()
+	svc := NewServiceWithAPI(f)
+
+	err := svc.Instance().
+		SetEngine("mysql").
+		SetDBInstanceIdentifier(TestDBIdentifier).
+		SetDBInstanceClass("db.m5.large").
+		SetAllocatedStorage(40).
+		Create(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+
+	// The defaults are kept, so the instance is polled once before the
+	// context ends instead of in a busy loop.
+	polls := 0
+	ctx, cancel := context.WithTimeout(context.TODO(), 50*time.Millisecond)
+	defer cancel()
+	err = svc.Instance().SetDBInstanceIdentifier(TestDBIdentifier).WaitUntilAvailable(ctx,
+		WithPollInterval(0),
+		WithTimeout(-time.Second),
+		WithProgress(func(WaitProgress) { polls++ }),
+	)
+	if !errors.Is(err, context.DeadlineExceeded) || polls != 1 {
+		t.Fatalf("expected context.DeadlineExceeded after 1 poll, got %+v after %d\n", err, polls)
+	}
+}
+
+func Test_WaitAuroraFailover(t *testing.T) {
+	f := fake.New().SetAutoAdvance(true)
+	svc := NewServiceWithAPI(f)
+
+	err := svc.Aurora().
+		SetEngine("aurora-mysql").
+		SetDBClusterIdentifier(TestDBIdentifier).
+		SetDBInstanceIdentifier("foo-instance-1").
+		SetDBInstanceClass("db.r5.large").
+		SetMasterUsername("admin").
+		SetMasterUserPassword(TestDBPass).
+		CreateWithPrimary(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	err = svc.Instance().
+		SetEngine("aurora-mysql").
+		SetDBInstanceIdentifier("foo-instance-2").
+		SetDBInstanceClass("db.r5.large").
+		SetDBClusterIdentifier(TestDBIdentifier).
+		Create(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+
+	aurora := svc.Aurora().SetDBClusterIdentifier(TestDBIdentifier)
+	if err := aurora.WaitUntilAvailable(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+
+	cluster := svc.Cluster().SetDBClusterIdentifier(TestDBIdentifier).SetTargetDBInstanceIdentifier("foo-instance-2")
+	if err := cluster.Failover(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := cluster.WaitUntilFailoverComplete(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	desc, err := aurora.Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if desc.Writer() != "foo-instance-2" {
+		t.Fatalf("expected foo-instance-2, got %s\n", desc.Writer())
+	}
+}
+
+// failedMemberAPI reports an instance as failed.
+type failedMemberAPI struct {
+	API
+	id string
+}
+
+func (a failedMemberAPI) DescribeDBInstances(ctx context.Context, params *rds.DescribeDBInstancesInput, optFns ...func(*rds.Options)) (*rds.DescribeDBInstancesOutput, error) {
+	output, err := a.API.DescribeDBInstances(ctx, params, optFns...)
+	if err != nil {
+		return nil, err
+	}
+	for n := range output.DBInstances {
+		if aws.ToString(output.DBInstances[n].DBInstanceIdentifier) == a.id {
+			output.DBInstances[n].DBInstanceStatus = aws.String("failed")
+		}
+	}
+	return output, nil
+}
+
+func Test_WaitAuroraFailedMember(t *testing.T) {
+	f := fake.New().SetAutoAdvance(true)
+	svc := NewServiceWithAPI(failedMemberAPI{API: f, id: "foo-instance-2"})
+
+	err := svc.Aurora().
+		SetEngine("aurora-mysql").
+		SetDBClusterIdentifier(TestDBIdentifier).
+		SetDBInstanceIdentifier("foo-instance-1").
+		SetDBInstanceClass("db.r5.large").
+		SetMasterUsername("admin").
+		SetMasterUserPassword(TestDBPass).
+		CreateWithPrimary(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	err = svc.Instance().
+		SetEngine("aurora-mysql").
+		SetDBInstanceIdentifier("foo-instance-2").
+		SetDBInstanceClass("db.r5.large").
+		SetDBClusterIdentifier(TestDBIdentifier).
+		Create(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+
+	err = svc.Aurora().SetDBClusterIdentifier(TestDBIdentifier).WaitUntilAvailable(context.TODO(),
+		WithPollInterval(time.Millisecond),
+		WithTimeout(time.Second),
+	)
+	if !errors.Is(err, ErrUnexpectedStatus) {
+		t.Fatalf("expected ErrUnexpectedStatus, got %+v\n", err)
+	}
+}
+
+// staleFailoverAPI describes the cluster as it was before FailoverDBCluster
+// once, as RDS does right after the call.
+type staleFailoverAPI struct {
+	API
+	stale *rds.DescribeDBClustersOutput
+}
+
+func (a *staleFailoverAPI) FailoverDBCluster(ctx context.Context, params *rds.FailoverDBClusterInput, optFns ...func(*rds.Options)) (*rds.FailoverDBClusterOutput, error) {
+	stale, err := a.API.DescribeDBClusters(ctx, &rds.DescribeDBClustersInput{DBClusterIdentifier: params.DBClusterIdentifier})
+	if err != nil {
+		return nil, err
+	}
+	output, err := a.API.FailoverDBCluster(ctx, params, optFns...)
+	if err != nil {
+		return nil, err
+	}
+	a.stale = stale
+	return output, nil
+}
+
+func (a *staleFailoverAPI) DescribeDBClusters(ctx context.Context, params *rds.DescribeDBClustersInput, optFns ...func(*rds.Options)) (*rds.DescribeDBClustersOutput, error) {
+	if stale := a.stale; stale != nil {
+		a.stale = nil
+		return stale, nil
+	}
+	return a.API.DescribeDBClusters(ctx, params, optFns...)
+}
+
+func Test_WaitFailoverStillAvailable(t *testing.T) {
+	f := fake.New().SetAutoAdvance(true)
+	svc := NewServiceWithAPI(&staleFailoverAPI{API: f})
+
+	err := svc.Aurora().
+		SetEngine("aurora-mysql").
+		SetDBClusterIdentifier(TestDBIdentifier).
+		SetDBInstanceIdentifier("foo-instance-1").
+		SetDBInstanceClass("db.r5.large").
+		SetMasterUsername("admin").
+		SetMasterUserPassword(TestDBPass).
+		CreateWithPrimary(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	err = svc.Instance().
+		SetEngine("aurora-mysql").
+		SetDBInstanceIdentifier("foo-instance-2").
+		SetDBInstanceClass("db.r5.large").
+		SetDBClusterIdentifier(TestDBIdentifier).
+		Create(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	aurora := svc.Aurora().SetDBClusterIdentifier(TestDBIdentifier)
+	if err := aurora.WaitUntilAvailable(context.TODO(), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+
+	// The first poll sees the cluster available with the old writer.
+	polls := 0
+	progress := WithProgress(func(WaitProgress) { polls++ })
+
+	cluster := svc.Cluster().SetDBClusterIdentifier(TestDBIdentifier)
+	if err := cluster.Failover(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := cluster.WaitUntilFailoverComplete(context.TODO(), WithPollInterval(time.Millisecond), progress); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if polls < 2 {
+		t.Fatalf("expected more than 1 poll, got %d\n", polls)
+	}
+	desc, err := aurora.Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if desc.Writer() != "foo-instance-2" {
+		t.Fatalf("expected foo-instance-2, got %s\n", desc.Writer())
+	}
+
+	polls = 0
+	if err := aurora.FailoverPrimary(context.TODO()); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if err := aurora.WaitUntilFailoverComplete(context.TODO(), WithPollInterval(time.Millisecond), progress); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if polls < 2 {
+		t.Fatalf("expected more than 1 poll, got %d\n", polls)
+	}
+	desc, err = aurora.Describe(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if desc.Writer() != "foo-instance-1" {
+		t.Fatalf("expected foo-instance-1, got %s\n", desc.Writer())
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awscfg "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+const (
+	EnvAWSRoleArn              = "AWS_ROLE_ARN"
+	EnvAWSWebIdentityTokenFile = "AWS_WEB_IDENTITY_TOKEN_FILE"
+)
+
+var ErrNoCredentials = errors.New("no credentials found")
+
+// CredentialSource resolves the credentials provider of a session. The base
+// config carries the region of the session and is used to build any STS
+// client the source needs.
+type CredentialSource interface {
+	Resolve(ctx context.Context, base aws.Config) (aws.CredentialsProvider, error)
+}
+
+type CredentialSourceFunc func(ctx context.Context, base aws.Config) (aws.CredentialsProvider, error)
+
+func (f CredentialSourceFunc) Resolve(ctx context.Context, base aws.Config) (aws.CredentialsProvider, error) {
+	return f(ctx, base)
+}
+
+// NewStaticCredentials uses a long-lived access key pair.
+func NewStaticCredentials(accessKey, secretAccessKey string) CredentialSource {
+	return NewSessionTokenCredentials(accessKey, secretAccessKey, "")
+}
+
+// NewSessionTokenCredentials uses temporary credentials issued by STS.
+func NewSessionTokenCredentials(accessKey, secretAccessKey, sessionToken string) CredentialSource {
+	return CredentialSourceFunc(func(_ context.Context, _ aws.Config) (aws.CredentialsProvider, error) {
+		return credentials.NewStaticCredentialsProvider(accessKey, secretAccessKey, sessionToken), nil
+	})
+}
+
+// NewEnvCredentials reads AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and
+// AWS_SESSION_TOKEN from the environment.
+func NewEnvCredentials() CredentialSource {
+	return CredentialSourceFunc(func(_ context.Context, _ aws.Config) (aws.CredentialsProvider, error) {
+		env, err := awscfg.NewEnvConfig()
+		if err != nil {
+			return nil, err
+		}
+		if !env.Credentials.HasKeys() {
+			return nil, fmt.Errorf("environment: %w", ErrNoCredentials)
+		}
+		return credentials.StaticCredentialsProvider{Value: env.Credentials}, nil
+	})
+}
+
+// NewProfileCredentials loads a profile from the shared config and credentials
+// files, including SSO and credential_process profiles.
+func NewProfileCredentials(profile string) CredentialSource {
+	return CredentialSourceFunc(func(ctx context.Context, base aws.Config) (aws.CredentialsProvider, error) {
+		cfg, err := awscfg.LoadDefaultConfig(ctx,
+			awscfg.WithRegion(base.Region),
+			awscfg.WithSharedConfigProfile(profile),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Credentials == nil {
+			return nil, fmt.Errorf("profile %s: %w", profile, ErrNoCredentials)
+		}
+		return cfg.Credentials, nil
+	})
+}
+
+// NewDefaultCredentials uses the default credential chain of the AWS SDK.
+func NewDefaultCredentials() CredentialSource {
+	return CredentialSourceFunc(func(ctx context.Context, base aws.Config) (aws.CredentialsProvider, error) {
+		cfg, err := awscfg.LoadDefaultConfig(ctx, awscfg.WithRegion(base.Region))
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Credentials == nil {
+			return nil, fmt.Errorf("default chain: %w", ErrNoCredentials)
+		}
+		return cfg.Credentials, nil
+	})
+}
+
+// NewWebIdentityCredentials exchanges an OIDC token for role credentials, as
+// used by IRSA. Empty arguments fall back to AWS_ROLE_ARN and
+// AWS_WEB_IDENTITY_TOKEN_FILE.
+func NewWebIdentityCredentials(roleArn, tokenFile string) CredentialSource {
+	return CredentialSourceFunc(func(_ context.Context, base aws.Config) (aws.CredentialsProvider, error) {
+		arn, file := roleArn, tokenFile
+		if strings.EqualFold(arn, "") {
+			arn = os.Getenv(EnvAWSRoleArn)
+		}
+		if strings.EqualFold(file, "") {
+			file = os.Getenv(EnvAWSWebIdentityTokenFile)
+		}
+		if strings.EqualFold(arn, "") || strings.EqualFold(file, "") {
+			return nil, fmt.Errorf("web identity: %w", ErrNoCredentials)
+		}
+
+		provider := stscreds.NewWebIdentityRoleProvider(
+			sts.NewFromConfig(base),
+			arn,
+			stscreds.IdentityTokenFile(file),
+		)
+		return aws.NewCredentialsCache(provider), nil
+	})
+}
+
+// NewChainCredentials tries every source in order. Sources which cannot be
+// resolved are skipped, and the remaining ones are asked for credentials in
+// turn until one of them succeeds.
+func NewChainCredentials(sources ...CredentialSource) CredentialSource {
+	return CredentialSourceFunc(func(ctx context.Context, base aws.Config) (aws.CredentialsProvider, error) {
+		chain := &chainProvider{}
+		errs := []string{}
+		for _, src := range sources {
+			p, err := src.Resolve(ctx, base)
+			if err != nil {
+				errs = append(errs, err.Error())
+				continue
+			}
+			chain.providers = append(chain.providers, p)
+		}
+		if len(chain.providers) == 0 {
+			return nil, fmt.Errorf("chain: %w: %s", ErrNoCredentials, strings.Join(errs, "; "))
+		}
+		return aws.NewCredentialsCache(chain), nil
+	})
+}
+
+type chainProvider struct {
+	providers []aws.CredentialsProvider
+}
+
+func (p *chainProvider) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	errs := []string{}
+	for _, provider := range p.providers {
+		creds, err := provider.Retrieve(ctx)
+		if err == nil {
+			return creds, nil
+		}
+		errs = append(errs, err.Error())
+	}
+	return aws.Credentials{}, fmt.Errorf("chain: %w: %s", ErrNoCredentials, strings.Join(errs, "; "))
+}
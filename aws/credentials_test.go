@@ -0,0 +1,109 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+const (
+	TestRegion          = "us-east-1"
+	TestAccessKey       = "ak-test"
+	TestSecretAccessKey = "sk-test"
+	TestSessionToken    = "token-test"
+)
+
+func Test_SessionTokenCredentials(t *testing.T) {
+	sess := NewSessions().
+		SetCredentialSource(TestRegion, NewSessionTokenCredentials(TestAccessKey, TestSecretAccessKey, TestSessionToken)).
+		Build()
+
+	creds, err := sess[TestRegion].Credentials.Retrieve(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if creds.AccessKeyID != TestAccessKey || creds.SessionToken != TestSessionToken {
+		t.Fatalf("unexpected credentials %#v\n", creds)
+	}
+}
+
+func Test_EnvCredentials(t *testing.T) {
+	clearEnvCredentials(t)
+	if _, err := NewEnvCredentials().Resolve(context.TODO(), aws.Config{Region: TestRegion}); !errors.Is(err, ErrNoCredentials) {
+		t.Fatalf("expected ErrNoCredentials, got %+v\n", err)
+	}
+
+	t.Setenv("AWS_ACCESS_KEY_ID", TestAccessKey)
+	t.Setenv("AWS_SECRET_ACCESS_KEY", TestSecretAccessKey)
+	provider, err := NewEnvCredentials().Resolve(context.TODO(), aws.Config{Region: TestRegion})
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	creds, err := provider.Retrieve(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if creds.AccessKeyID != TestAccessKey {
+		t.Fatalf("unexpected credentials %#v\n", creds)
+	}
+}
+
+func Test_ChainCredentialsFallback(t *testing.T) {
+	clearEnvCredentials(t)
+	t.Setenv(EnvAWSRoleArn, "")
+	t.Setenv(EnvAWSWebIdentityTokenFile, "")
+
+	sess := NewSessions().
+		SetCredentialSource(TestRegion, NewChainCredentials(
+			NewWebIdentityCredentials("", ""),
+			NewEnvCredentials(),
+			NewStaticCredentials(TestAccessKey, TestSecretAccessKey),
+		)).
+		Build()
+
+	creds, err := sess[TestRegion].Credentials.Retrieve(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if creds.AccessKeyID != TestAccessKey {
+		t.Fatalf("unexpected credentials %#v\n", creds)
+	}
+}
+
+func Test_WebIdentityCredentialsFromEnv(t *testing.T) {
+	t.Setenv(EnvAWSRoleArn, "arn:aws:iam::123456789012:role/test")
+	t.Setenv(EnvAWSWebIdentityTokenFile, "/var/run/secrets/token")
+	src := NewWebIdentityCredentials("", "")
+	if _, err := src.Resolve(context.TODO(), aws.Config{Region: TestRegion}); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+
+	// The environment is read again on every Resolve.
+	t.Setenv(EnvAWSRoleArn, "")
+	if _, err := src.Resolve(context.TODO(), aws.Config{Region: TestRegion}); !errors.Is(err, ErrNoCredentials) {
+		t.Fatalf("expected ErrNoCredentials, got %+v\n", err)
+	}
+}
+
+func clearEnvCredentials(t *testing.T) {
+	t.Helper()
+	for _, k := range []string{"AWS_ACCESS_KEY_ID", "AWS_ACCESS_KEY", "AWS_SECRET_ACCESS_KEY", "AWS_SECRET_KEY", "AWS_SESSION_TOKEN"} {
+		t.Setenv(k, "")
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"crypto/tls"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	awscfg "github.com/aws/aws-sdk-go-v2/config"
+)
+
+// endpointOptions point sessions at stand-ins of AWS such as LocalStack or
+// an in-process fake.
+type endpointOptions struct {
+	// endpoints maps a service ID, e.g. "RDS", to its URL. The empty service
+	// ID applies to every service without an endpoint of its own.
+	endpoints         map[string]string
+	hostnameImmutable bool
+	tlsConfig         *tls.Config
+}
+
+// SetEndpoint sends the requests of every service to url, which is how
+// LocalStack-style stand-ins serve all APIs from one address.
+func (s *awsCreds) SetEndpoint(url string) *awsCreds {
+	return s.SetServiceEndpoint("", url)
+}
+
+// SetServiceEndpoint sends the requests of one service, e.g. rds.ServiceID,
+// to url.
+func (s *awsCreds) SetServiceEndpoint(serviceID, url string) *awsCreds {
+	if s.endpoint.endpoints == nil {
+		s.endpoint.endpoints = map[string]string{}
+	}
+	s.endpoint.endpoints[serviceID] = url
+	return s
+}
+
+// SetHostnameImmutable keeps the host of custom endpoints as is, i.e.
+// path-style addressing, instead of letting the SDK prefix it.
+func (s *awsCreds) SetHostnameImmutable(enable bool) *awsCreds {
+	s.endpoint.hostnameImmutable = enable
+	return s
+}
+
+// SetTLSConfig sets the TLS config of the HTTP client, e.g. to trust the
+// certificate authority of a stand-in.
+func (s *awsCreds) SetTLSConfig(cfg *tls.Config) *awsCreds {
+	s.endpoint.tlsConfig = cfg
+	return s
+}
+
+// SetInsecureSkipVerify disables certificate verification. It is meant for
+// local stand-ins only.
+func (s *awsCreds) SetInsecureSkipVerify(skip bool) *awsCreds {
+	if s.endpoint.tlsConfig == nil {
+		s.endpoint.tlsConfig = &tls.Config{}
+	}
+	s.endpoint.tlsConfig.InsecureSkipVerify = skip
+	return s
+}
+
+func (o endpointOptions) loadOptions() []func(*awscfg.LoadOptions) error {
+	opts := []func(*awscfg.LoadOptions) error{}
+	if len(o.endpoints) > 0 {
+		opts = append(opts, awscfg.WithEndpointResolverWithOptions(o.resolver()))
+	}
+	if o.tlsConfig != nil {
+		tlsConfig := o.tlsConfig.Clone()
+		opts = append(opts, awscfg.WithHTTPClient(awshttp.NewBuildableClient().WithTransportOptions(func(tr *http.Transport) {
+			tr.TLSClientConfig = tlsConfig
+		})))
+	}
+	return opts
+}
+
+func (o endpointOptions) resolver() aws.EndpointResolverWithOptions {
+	endpoints := map[string]string{}
+	for k, v := range o.endpoints {
+		endpoints[k] = v
+	}
+	return aws.EndpointResolverWithOptionsFunc(func(service, region string, _ ...interface{}) (aws.Endpoint, error) {
+		url, ok := endpoints[service]
+		if !ok {
+			url, ok = endpoints[""]
+		}
+		if !ok || strings.EqualFold(url, "") {
+			// Fall back to the default endpoint of the service.
+			return aws.Endpoint{}, &aws.EndpointNotFoundError{}
+		}
+		return aws.Endpoint{
+			URL:               url,
+			SigningRegion:     region,
+			HostnameImmutable: o.hostnameImmutable,
+			Source:            aws.EndpointSourceCustom,
+		}, nil
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrEmptyRegion     = errors.New("region is empty")
+	ErrSessionNotFound = errors.New("session not found")
+)
+
+// CredentialError describes a credential entry whose session failed to build.
+type CredentialError struct {
+	// Index is the position of the entry in the order it was set.
+	Index   int
+	Account string
+	Region  string
+	Err     error
+}
+
+func (e *CredentialError) Error() string {
+	if strings.EqualFold(e.Account, DefaultAccount) {
+		return fmt.Sprintf("credential #%d for region %q: %v", e.Index, e.Region, e.Err)
+	}
+	return fmt.Sprintf("credential #%d for account %q region %q: %v", e.Index, e.Account, e.Region, e.Err)
+}
+
+func (e *CredentialError) Unwrap() error {
+	return e.Err
+}
+
+// BuildError collects every failed credential entry of a build.
+type BuildError struct {
+	Errors []*CredentialError
+}
+
+func (e *BuildError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("failed to build %d session(s): %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// Is reports whether any failed entry matches target. Unwrap []error is only
+// walked by errors.Is since Go 1.20.
+func (e *BuildError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first failed entry matching target, as Is does.
+func (e *BuildError) As(target interface{}) bool {
+	for _, err := range e.Errors {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *BuildError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
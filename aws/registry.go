@@ -0,0 +1,131 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+)
+
+// DefaultAccount is the account of the credentials set without one.
+const DefaultAccount = ""
+
+// SessionKey identifies a session by account and region. The account is an
+// AWS account ID or any other name, e.g. a profile, chosen by the caller.
+type SessionKey struct {
+	Account string
+	Region  string
+}
+
+func (k SessionKey) String() string {
+	return fmt.Sprintf("%s/%s", k.Account, k.Region)
+}
+
+// Registry holds sessions of several accounts, unlike Sessions which keeps
+// a single session per region.
+type Registry map[SessionKey]aws.Config
+
+func (s *awsCreds) SetAccountCredential(account, region, accessKey, secretAccessKey string) *awsCreds {
+	return s.SetAccountCredentialSource(account, region, NewStaticCredentials(accessKey, secretAccessKey))
+}
+
+func (s *awsCreds) SetAccountCredentialSource(account, region string, source CredentialSource) *awsCreds {
+	s.credentials = append(s.credentials, credential{
+		account: account,
+		region:  region,
+		source:  source,
+	})
+	return s
+}
+
+// BuildRegistry skips the credentials whose session could not be built.
+func (s *awsCreds) BuildRegistry() Registry {
+	sess, _ := s.build()
+	return sess.registry()
+}
+
+// BuildRegistryStrict builds every valid session and returns a *BuildError
+// that describes each credential entry which failed.
+func (s *awsCreds) BuildRegistryStrict() (Registry, error) {
+	sess, errs := s.build()
+	if len(errs) > 0 {
+		return sess.registry(), &BuildError{Errors: errs}
+	}
+	return sess.registry(), nil
+}
+
+// Get returns the session of account in region, or ErrSessionNotFound.
+func (r Registry) Get(account, region string) (aws.Config, error) {
+	cfg, ok := r[SessionKey{Account: account, Region: region}]
+	if !ok {
+		return aws.Config{}, fmt.Errorf("account %s region %s: %w", account, region, ErrSessionNotFound)
+	}
+	return cfg, nil
+}
+
+// GetByArn returns the session matching the account and region of a
+// resource ARN, e.g. the one reported in a DatabaseEndpoint status. It only
+// works for accounts registered by their account ID.
+func (r Registry) GetByArn(resourceArn string) (aws.Config, error) {
+	a, err := arn.Parse(resourceArn)
+	if err != nil {
+		return aws.Config{}, err
+	}
+	return r.Get(a.AccountID, a.Region)
+}
+
+// Sessions returns the sessions of account keyed by region.
+func (r Registry) Sessions(account string) Sessions {
+	sess := Sessions{}
+	for k, v := range r {
+		if k.Account == account {
+			sess[k.Region] = v
+		}
+	}
+	return sess
+}
+
+// Accounts returns the sorted accounts of the registry.
+func (r Registry) Accounts() []string {
+	seen := map[string]struct{}{}
+	accounts := []string{}
+	for k := range r {
+		if _, ok := seen[k.Account]; ok {
+			continue
+		}
+		seen[k.Account] = struct{}{}
+		accounts = append(accounts, k.Account)
+	}
+	sort.Strings(accounts)
+	return accounts
+}
+
+// Keys returns the sorted keys of the registry.
+func (r Registry) Keys() []SessionKey {
+	keys := make([]SessionKey, 0, len(r))
+	for k := range r {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Account != keys[j].Account {
+			return keys[i].Account < keys[j].Account
+		}
+		return keys[i].Region < keys[j].Region
+	})
+	return keys
+}
@@ -0,0 +1,131 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	awscfg "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/smithy-go/middleware"
+	"golang.org/x/time/rate"
+)
+
+const rateLimitMiddlewareID = "ClientRateLimit"
+
+// retryOptions tune how throttled and failed requests are retried, and how
+// fast requests may be sent at all.
+type retryOptions struct {
+	mode        aws.RetryMode
+	maxAttempts int
+	maxBackoff  time.Duration
+
+	// rateLimit and rateBurst configure a token bucket per session, shared
+	// by every client created from it.
+	rateLimit rate.Limit
+	rateBurst int
+}
+
+// SetRetryMode selects aws.RetryModeStandard or aws.RetryModeAdaptive. The
+// adaptive mode also slows down requests when they get throttled.
+func (s *awsCreds) SetRetryMode(mode aws.RetryMode) *awsCreds {
+	s.retry.mode = mode
+	return s
+}
+
+// SetMaxAttempts sets the maximum number of attempts of a request, including
+// the first one.
+func (s *awsCreds) SetMaxAttempts(n int) *awsCreds {
+	s.retry.maxAttempts = n
+	return s
+}
+
+// SetMaxBackoff caps the delay between two attempts.
+func (s *awsCreds) SetMaxBackoff(d time.Duration) *awsCreds {
+	s.retry.maxBackoff = d
+	return s
+}
+
+// SetRateLimit allows at most rps requests per second with bursts of burst
+// requests. Every session gets its own bucket, so the limit applies per
+// account and region, which is how AWS throttles its control plane. A burst
+// of 0 or less defaults to one second of requests, as a bucket without burst
+// would reject every request.
+func (s *awsCreds) SetRateLimit(rps float64, burst int) *awsCreds {
+	if burst <= 0 {
+		burst = int(math.Ceil(rps))
+		if burst < 1 {
+			burst = 1
+		}
+	}
+	s.retry.rateLimit = rate.Limit(rps)
+	s.retry.rateBurst = burst
+	return s
+}
+
+// loadOptions is called once per session so that the rate limiter is not
+// shared between sessions.
+func (o retryOptions) loadOptions() []func(*awscfg.LoadOptions) error {
+	opts := []func(*awscfg.LoadOptions) error{}
+	if o.mode != "" || o.maxAttempts > 0 || o.maxBackoff > 0 {
+		opts = append(opts, awscfg.WithRetryer(o.retryer))
+	}
+	if o.rateLimit > 0 {
+		limiter := rate.NewLimiter(o.rateLimit, o.rateBurst)
+		opts = append(opts, awscfg.WithAPIOptions([]func(*middleware.Stack) error{
+			func(stack *middleware.Stack) error {
+				return addRateLimitMiddleware(stack, limiter)
+			},
+		}))
+	}
+	return opts
+}
+
+func (o retryOptions) retryer() aws.Retryer {
+	standard := func(so *retry.StandardOptions) {
+		if o.maxAttempts > 0 {
+			so.MaxAttempts = o.maxAttempts
+		}
+		if o.maxBackoff > 0 {
+			so.MaxBackoff = o.maxBackoff
+		}
+	}
+	if o.mode == aws.RetryModeAdaptive {
+		return retry.NewAdaptiveMode(func(ao *retry.AdaptiveModeOptions) {
+			ao.StandardOptions = append(ao.StandardOptions, standard)
+		})
+	}
+	return retry.NewStandard(standard)
+}
+
+// addRateLimitMiddleware waits for the limiter before every attempt, so
+// retries are limited as well.
+func addRateLimitMiddleware(stack *middleware.Stack, limiter *rate.Limiter) error {
+	mw := middleware.FinalizeMiddlewareFunc(rateLimitMiddlewareID, func(
+		ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler,
+	) (middleware.FinalizeOutput, middleware.Metadata, error) {
+		if err := limiter.Wait(ctx); err != nil {
+			return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+		}
+		return next.HandleFinalize(ctx, in)
+	})
+	if _, ok := stack.Finalize.Get((&retry.Attempt{}).ID()); ok {
+		return stack.Finalize.Insert(mw, (&retry.Attempt{}).ID(), middleware.After)
+	}
+	return stack.Finalize.Add(mw, middleware.After)
+}
@@ -0,0 +1,238 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	SecretKeyAccessKey       = "AWS_ACCESS_KEY_ID"
+	SecretKeySecretAccessKey = "AWS_SECRET_ACCESS_KEY"
+	SecretKeySessionToken    = "AWS_SESSION_TOKEN"
+
+	// DefaultSecretRewatchInterval is how long to wait before watching the
+	// Secret again after its watch was closed or failed.
+	DefaultSecretRewatchInterval = 5 * time.Second
+)
+
+var secretGVR = schema.GroupVersionResource{Group: "", Version: "v1", Resource: "secrets"}
+
+// SecretCredentials serves the credentials stored in a Kubernetes Secret and
+// follows its updates. Every session built with it shares the same provider,
+// so rotated keys are used by existing configs and clients without rebuilding
+// them. The client is usually the one of kubernetes/client, i.e.
+// client.GetClient().Client.
+type SecretCredentials struct {
+	client    dynamic.Interface
+	namespace string
+	name      string
+
+	accessKeyKey       string
+	secretAccessKeyKey string
+	sessionTokenKey    string
+
+	// startMu guards started and cancel. started is set once the Secret
+	// was read and is being watched.
+	startMu sync.Mutex
+	started bool
+	cancel  context.CancelFunc
+
+	mu     sync.RWMutex
+	value  aws.Credentials
+	err    error
+	notify []func(aws.Credentials)
+}
+
+var _ CredentialSource = &SecretCredentials{}
+var _ aws.CredentialsProvider = &SecretCredentials{}
+
+func NewSecretCredentials(client dynamic.Interface, namespace, name string) *SecretCredentials {
+	return &SecretCredentials{
+		client:             client,
+		namespace:          namespace,
+		name:               name,
+		accessKeyKey:       SecretKeyAccessKey,
+		secretAccessKeyKey: SecretKeySecretAccessKey,
+		sessionTokenKey:    SecretKeySessionToken,
+	}
+}
+
+// SetKeys overrides the data keys the credentials are read from.
+func (s *SecretCredentials) SetKeys(accessKey, secretAccessKey, sessionToken string) *SecretCredentials {
+	s.accessKeyKey = accessKey
+	s.secretAccessKeyKey = secretAccessKey
+	s.sessionTokenKey = sessionToken
+	return s
+}
+
+// OnRotate registers a callback invoked with the new credentials whenever
+// the Secret changes.
+func (s *SecretCredentials) OnRotate(fn func(aws.Credentials)) *SecretCredentials {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notify = append(s.notify, fn)
+	return s
+}
+
+// Resolve reads the Secret and starts watching it. It fails if the Secret
+// cannot be read or does not hold credentials.
+func (s *SecretCredentials) Resolve(ctx context.Context, _ aws.Config) (aws.CredentialsProvider, error) {
+	s.startMu.Lock()
+	defer s.startMu.Unlock()
+	if s.started {
+		return s, nil
+	}
+
+	// Failures leave started unset, so that a later Resolve tries again.
+	obj, err := s.client.Resource(secretGVR).Namespace(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	s.update(obj)
+	s.mu.RLock()
+	err = s.err
+	s.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	wctx, cancel := context.WithCancel(context.Background())
+	s.started, s.cancel = true, cancel
+	go s.watch(wctx, obj.GetResourceVersion())
+	return s, nil
+}
+
+func (s *SecretCredentials) Retrieve(_ context.Context) (aws.Credentials, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.err != nil {
+		return aws.Credentials{}, s.err
+	}
+	return s.value, nil
+}
+
+// Stop stops watching the Secret. The last credentials stay available, and
+// the next Resolve reads and watches the Secret again.
+func (s *SecretCredentials) Stop() {
+	s.startMu.Lock()
+	defer s.startMu.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.started, s.cancel = false, nil
+}
+
+func (s *SecretCredentials) watch(ctx context.Context, resourceVersion string) {
+	for {
+		w, err := s.client.Resource(secretGVR).Namespace(s.namespace).Watch(ctx, metav1.ListOptions{
+			FieldSelector:   fields.OneTermEqualSelector("metadata.name", s.name).String(),
+			ResourceVersion: resourceVersion,
+		})
+		if err == nil {
+			resourceVersion = s.consume(ctx, w)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(DefaultSecretRewatchInterval):
+		}
+	}
+}
+
+// consume handles events until the watch is closed and returns the last
+// resource version seen.
+func (s *SecretCredentials) consume(ctx context.Context, w watch.Interface) string {
+	defer w.Stop()
+	resourceVersion := ""
+	for {
+		select {
+		case <-ctx.Done():
+			return resourceVersion
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return resourceVersion
+			}
+			obj, ok := event.Object.(*unstructured.Unstructured)
+			if !ok || obj.GetName() != s.name {
+				continue
+			}
+			resourceVersion = obj.GetResourceVersion()
+			// A deleted Secret keeps serving the last credentials.
+			if event.Type == watch.Added || event.Type == watch.Modified {
+				s.update(obj)
+			}
+		}
+	}
+}
+
+func (s *SecretCredentials) update(obj *unstructured.Unstructured) {
+	data, _, _ := unstructured.NestedStringMap(obj.Object, "data")
+	value := aws.Credentials{Source: fmt.Sprintf("Secret %s/%s", s.namespace, s.name)}
+	var err error
+	if value.AccessKeyID, err = decodeSecretData(data, s.accessKeyKey); err == nil {
+		if value.SecretAccessKey, err = decodeSecretData(data, s.secretAccessKeyKey); err == nil {
+			value.SessionToken, err = decodeSecretData(data, s.sessionTokenKey)
+		}
+	}
+	if err == nil && !value.HasKeys() {
+		err = fmt.Errorf("%s: %w", value.Source, ErrNoCredentials)
+	}
+
+	s.mu.Lock()
+	// Keep serving the last valid credentials if an update is malformed.
+	if err != nil {
+		if !s.value.HasKeys() {
+			s.err = err
+		}
+		s.mu.Unlock()
+		return
+	}
+	changed := s.value != value
+	s.value, s.err = value, nil
+	notify := s.notify
+	s.mu.Unlock()
+
+	if changed {
+		for _, fn := range notify {
+			fn(value)
+		}
+	}
+}
+
+func decodeSecretData(data map[string]string, key string) (string, error) {
+	if strings.EqualFold(key, "") {
+		return "", nil
+	}
+	v, err := base64.StdEncoding.DecodeString(data[key])
+	if err != nil {
+		return "", fmt.Errorf("secret key %s: %w", key, err)
+	}
+	return string(v), nil
+}
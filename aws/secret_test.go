@@ -0,0 +1,186 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+const (
+	TestSecretNamespace = "default"
+	TestSecretName      = "aws-credentials"
+)
+
+func newCredentialSecret(t *testing.T, accessKey string) *unstructured.Unstructured {
+	t.Helper()
+	secret := &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: TestSecretNamespace, Name: TestSecretName},
+		Data: map[string][]byte{
+			SecretKeyAccessKey:       []byte(accessKey),
+			SecretKeySecretAccessKey: []byte(TestSecretAccessKey),
+		},
+	}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(secret)
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	return &unstructured.Unstructured{Object: obj}
+}
+
+func Test_SecretCredentialsRotation(t *testing.T) {
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), newCredentialSecret(t, "ak-1"))
+	source := NewSecretCredentials(client, TestSecretNamespace, TestSecretName)
+	defer source.Stop()
+
+	rotated := make(chan aws.Credentials, 2)
+	source.OnRotate(func(c aws.Credentials) { rotated <- c })
+
+	sess, err := NewSessions().SetCredentialSource(TestRegion, source).BuildStrict()
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	<-rotated
+
+	creds, err := sess[TestRegion].Credentials.Retrieve(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if creds.AccessKeyID != "ak-1" {
+		t.Fatalf("unexpected credentials %#v\n", creds)
+	}
+
+	// The fake client does not replay events missed before the watch is
+	// established, so keep updating until the rotation is seen.
+	timeout := time.After(10 * time.Second)
+	for done := false; !done; {
+		if _, err := client.Resource(secretGVR).Namespace(TestSecretNamespace).Update(context.TODO(), newCredentialSecret(t, "ak-2"), metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("%+v\n", err)
+		}
+		select {
+		case <-rotated:
+			done = true
+		case <-time.After(100 * time.Millisecond):
+		case <-timeout:
+			t.Fatalf("credentials were not rotated\n")
+		}
+	}
+	creds, err = sess[TestRegion].Credentials.Retrieve(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if creds.AccessKeyID != "ak-2" {
+		t.Fatalf("unexpected credentials %#v\n", creds)
+	}
+}
+
+func Test_SecretCredentialsConcurrentResolve(t *testing.T) {
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	source := NewSecretCredentials(client, TestSecretNamespace, TestSecretName)
+	defer source.Stop()
+
+	resolve := func() []error {
+		errs := make([]error, 8)
+		var wg sync.WaitGroup
+		for n := range errs {
+			wg.Add(1)
+			go func(n int) {
+				defer wg.Done()
+				_, errs[n] = source.Resolve(context.TODO(), aws.Config{Region: TestRegion})
+			}(n)
+		}
+		wg.Wait()
+		return errs
+	}
+
+	for _, err := range resolve() {
+		if err == nil {
+			t.Fatalf("expected secret not found error\n")
+		}
+	}
+	// Failed resolutions are tried again once the Secret exists.
+	if _, err := client.Resource(secretGVR).Namespace(TestSecretNamespace).Create(context.TODO(), newCredentialSecret(t, "ak-1"), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	for _, err := range resolve() {
+		if err != nil {
+			t.Fatalf("%+v\n", err)
+		}
+	}
+	creds, err := source.Retrieve(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if creds.AccessKeyID != "ak-1" {
+		t.Fatalf("unexpected credentials %#v\n", creds)
+	}
+}
+
+func Test_SecretCredentialsStopThenResolve(t *testing.T) {
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), newCredentialSecret(t, "ak-1"))
+	source := NewSecretCredentials(client, TestSecretNamespace, TestSecretName)
+	defer source.Stop()
+
+	if _, err := source.Resolve(context.TODO(), aws.Config{Region: TestRegion}); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	source.Stop()
+
+	// The Secret is read again and watched after Stop.
+	if _, err := client.Resource(secretGVR).Namespace(TestSecretNamespace).Update(context.TODO(), newCredentialSecret(t, "ak-2"), metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if _, err := source.Resolve(context.TODO(), aws.Config{Region: TestRegion}); err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	creds, err := source.Retrieve(context.TODO())
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	if creds.AccessKeyID != "ak-2" {
+		t.Fatalf("unexpected credentials %#v\n", creds)
+	}
+
+	rotated := make(chan aws.Credentials, 1)
+	source.OnRotate(func(c aws.Credentials) {
+		select {
+		case rotated <- c:
+		default:
+		}
+	})
+	timeout := time.After(10 * time.Second)
+	for done := false; !done; {
+		if _, err := client.Resource(secretGVR).Namespace(TestSecretNamespace).Update(context.TODO(), newCredentialSecret(t, "ak-3"), metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("%+v\n", err)
+		}
+		select {
+		case c := <-rotated:
+			done = c.AccessKeyID == "ak-3"
+		case <-time.After(100 * time.Millisecond):
+		case <-timeout:
+			t.Fatalf("credentials were not rotated\n")
+		}
+	}
+}
@@ -35,6 +35,8 @@ type DatabaseClassSpec struct {
 	Engine      DatabaseEngine      `json:"engine"`
 	Instance    DatabaseInstance    `json:"instance"`
 	Storage     DatabaseStorage     `json:"storage"`
+	// +optional
+	Serverless *DatabaseServerless `json:"serverless,omitempty"`
 }
 
 type DatabaseProvisioner string
@@ -63,6 +65,13 @@ const (
 	AnnotationsAvailabilityZones   = "databaseclass.database-mesh.io/availability-zones"
 )
 
+// AnnotationsAWSAccount and AnnotationsAWSRegion select the AWS session of a
+// DatabaseClass or DatabaseEndpoint, so they are not prefixed by either kind.
+const (
+	AnnotationsAWSAccount = "database-mesh.io/aws-account"
+	AnnotationsAWSRegion  = "database-mesh.io/aws-region"
+)
+
 type DatabaseStorage struct {
 	// +optional
 	AllocatedStorage int32 `json:"allocatedStorage"`
@@ -70,6 +79,29 @@ type DatabaseStorage struct {
 	IOPS int32 `json:"iops"`
 }
 
+type DatabaseServerlessVersion string
+
+const (
+	// DatabaseServerlessV1 is Aurora Serverless v1, it needs the serverless
+	// engine mode.
+	DatabaseServerlessV1 DatabaseServerlessVersion = "v1"
+	// DatabaseServerlessV2 is Aurora Serverless v2, whose instances default
+	// to the db.serverless class.
+	DatabaseServerlessV2 DatabaseServerlessVersion = "v2"
+)
+
+// DatabaseServerless is the scaling configuration of Aurora Serverless.
+// NOTE: Capacities are strings since Serverless v2 uses half ACUs, e.g. "0.5".
+type DatabaseServerless struct {
+	Version     DatabaseServerlessVersion `json:"version"`
+	MinCapacity string                    `json:"minCapacity"`
+	MaxCapacity string                    `json:"maxCapacity"`
+	// +optional
+	AutoPause bool `json:"autoPause"`
+	// +optional
+	SecondsUntilAutoPause int32 `json:"secondsUntilAutoPause"`
+}
+
 type DatabaseClassStatus struct{}
 
 // +kubebuilder:object:root=true
@@ -426,7 +426,7 @@ func (in *DatabaseClass) DeepCopyInto(out *DatabaseClass) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	out.Status = in.Status
 }
 
@@ -486,6 +486,11 @@ func (in *DatabaseClassSpec) DeepCopyInto(out *DatabaseClassSpec) {
 	out.Engine = in.Engine
 	out.Instance = in.Instance
 	out.Storage = in.Storage
+	if in.Serverless != nil {
+		in, out := &in.Serverless, &out.Serverless
+		*out = new(DatabaseServerless)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseClassSpec.
@@ -668,6 +673,21 @@ func (in *DatabaseService) DeepCopy() *DatabaseService {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseServerless) DeepCopyInto(out *DatabaseServerless) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseServerless.
+func (in *DatabaseServerless) DeepCopy() *DatabaseServerless {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseServerless)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatabaseStorage) DeepCopyInto(out *DatabaseStorage) {
 	*out = *in
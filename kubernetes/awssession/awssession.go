@@ -0,0 +1,43 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package awssession picks the AWS session of DatabaseClass and
+// DatabaseEndpoint objects from an aws.Registry, so that the aws package does
+// not depend on the CRD types.
+package awssession
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	dbmesh "github.com/database-mesh/golang-sdk/aws"
+	"github.com/database-mesh/golang-sdk/kubernetes/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ForObject returns the session a DatabaseClass or DatabaseEndpoint points
+// at with the aws-account and aws-region annotations. The account defaults to
+// aws.DefaultAccount. A DatabaseEndpoint without a region annotation falls
+// back to the ARN of its status.
+func ForObject(reg dbmesh.Registry, obj metav1.Object) (aws.Config, error) {
+	annotations := obj.GetAnnotations()
+	region := annotations[v1alpha1.AnnotationsAWSRegion]
+	if region == "" {
+		if ep, ok := obj.(*v1alpha1.DatabaseEndpoint); ok && ep.Status.Arn != "" {
+			return reg.GetByArn(ep.Status.Arn)
+		}
+		return aws.Config{}, fmt.Errorf("%s: %w", obj.GetName(), dbmesh.ErrEmptyRegion)
+	}
+	return reg.Get(annotations[v1alpha1.AnnotationsAWSAccount], region)
+}
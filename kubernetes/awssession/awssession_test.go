@@ -0,0 +1,95 @@
+// Copyright 2023 SphereEx Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awssession
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	dbmesh "github.com/database-mesh/golang-sdk/aws"
+	"github.com/database-mesh/golang-sdk/kubernetes/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	TestRegion          = "us-east-1"
+	TestSecretAccessKey = "sk-test"
+)
+
+func Test_ForObject(t *testing.T) {
+	reg := dbmesh.NewSessions().
+		SetAccountCredential("111111111111", TestRegion, "ak-1", TestSecretAccessKey).
+		SetAccountCredential("222222222222", "us-west-2", "ak-2", TestSecretAccessKey).
+		SetCredential(TestRegion, "ak-default", TestSecretAccessKey).
+		BuildRegistry()
+
+	expectAccessKey := func(cfg aws.Config, key string) {
+		t.Helper()
+		creds, err := cfg.Credentials.Retrieve(context.TODO())
+		if err != nil {
+			t.Fatalf("%+v\n", err)
+		}
+		if creds.AccessKeyID != key {
+			t.Fatalf("unexpected credentials %#v\n", creds)
+		}
+	}
+
+	class := &v1alpha1.DatabaseClass{ObjectMeta: metav1.ObjectMeta{
+		Name: "aurora",
+		Annotations: map[string]string{
+			v1alpha1.AnnotationsAWSAccount: "111111111111",
+			v1alpha1.AnnotationsAWSRegion:  TestRegion,
+		},
+	}}
+	cfg, err := ForObject(reg, class)
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	expectAccessKey(cfg, "ak-1")
+
+	// The account defaults to the one of the credentials set without one.
+	class.Annotations = map[string]string{v1alpha1.AnnotationsAWSRegion: TestRegion}
+	cfg, err = ForObject(reg, class)
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	expectAccessKey(cfg, "ak-default")
+
+	class.Annotations = nil
+	if _, err := ForObject(reg, class); !errors.Is(err, dbmesh.ErrEmptyRegion) {
+		t.Fatalf("expected ErrEmptyRegion, got %+v\n", err)
+	}
+
+	// Endpoints without annotations are found by the ARN of their status.
+	endpoint := &v1alpha1.DatabaseEndpoint{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+		Status:     v1alpha1.DatabaseEndpointStatus{Arn: "arn:aws:rds:us-west-2:222222222222:db:foo"},
+	}
+	cfg, err = ForObject(reg, endpoint)
+	if err != nil {
+		t.Fatalf("%+v\n", err)
+	}
+	expectAccessKey(cfg, "ak-2")
+
+	endpoint.Annotations = map[string]string{
+		v1alpha1.AnnotationsAWSAccount: "222222222222",
+		v1alpha1.AnnotationsAWSRegion:  TestRegion,
+	}
+	if _, err := ForObject(reg, endpoint); !errors.Is(err, dbmesh.ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound, got %+v\n", err)
+	}
+}